@@ -0,0 +1,108 @@
+package aoc
+
+import (
+	"advent-of-code-2025/support"
+	"context"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// Timing and allocation statistics for one phase of a day (parsing, or solving one part) over repeated runs.
+type PhaseStats struct {
+	Phase       string        `json:"phase"`
+	Runs        int           `json:"runs"`
+	Min         time.Duration `json:"min_ns"`
+	Median      time.Duration `json:"median_ns"`
+	P95         time.Duration `json:"p95_ns"`
+	AllocsPerOp uint64        `json:"allocs_per_op"`
+	BytesPerOp  uint64        `json:"bytes_per_op"`
+}
+
+type Benchmark struct {
+	Day       int          `json:"day"`
+	InputHash string       `json:"input_hash,omitempty"`
+	Phases    []PhaseStats `json:"phases,omitempty"`
+	Error     string       `json:"error,omitempty"` // Set instead of the phases if the day couldn't be benchmarked
+}
+
+// Load the day's input once, then parse it and solve each part runs times, timing each phase separately.
+func (d Day) Benchmark(source support.InputSource, runs int) (Benchmark, error) {
+	input, err := source.Load(d.Number)
+	if err != nil {
+		return Benchmark{}, err
+	}
+
+	phases := []*phaseSamples{{name: "parse"}, {name: "part 1"}, {name: "part 2"}}
+
+	for range runs {
+		var parsed any
+
+		phases[0].measure(func() { parsed, err = d.Parse(input) })
+		if err != nil {
+			return Benchmark{}, err
+		}
+
+		for part := 1; part <= len(d.parts); part++ {
+			phases[part].measure(func() { _, err = d.Solve(context.Background(), part, parsed) })
+			if err != nil {
+				return Benchmark{}, err
+			}
+		}
+	}
+
+	return Benchmark{
+		Day:       d.Number,
+		InputHash: HashInput(input),
+		Phases:    support.Map(phases, (*phaseSamples).stats),
+	}, nil
+}
+
+type phaseSamples struct {
+	name      string
+	durations []time.Duration
+	allocs    uint64
+	bytes     uint64
+}
+
+// Run fn once, recording how long it took and how much it allocated
+func (p *phaseSamples) measure(fn func()) {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	fn()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	p.durations = append(p.durations, elapsed)
+	p.allocs += after.Mallocs - before.Mallocs
+	p.bytes += after.TotalAlloc - before.TotalAlloc
+}
+
+func (p *phaseSamples) stats() PhaseStats {
+	runs := len(p.durations)
+	if runs == 0 {
+		return PhaseStats{Phase: p.name}
+	}
+
+	sorted := slices.Sorted(slices.Values(p.durations))
+
+	return PhaseStats{
+		Phase:       p.name,
+		Runs:        runs,
+		Min:         sorted[0],
+		Median:      sorted[runs/2],
+		P95:         sorted[percentileIndex(runs, 95)],
+		AllocsPerOp: p.allocs / uint64(runs),
+		BytesPerOp:  p.bytes / uint64(runs),
+	}
+}
+
+// Nearest-rank index of the given percentile in a sorted slice of length n
+func percentileIndex(n, percentile int) int {
+	// Ceiling division, then back to a zero-based index
+	return max((n*percentile+99)/100-1, 0)
+}
@@ -0,0 +1,147 @@
+package aoc
+
+import (
+	"bufio"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A Ledger records accepted answers, keyed by day, part and a hash of the input, so that everyone's inputs can share
+// one checked-in file and later runs can be compared against them.
+//
+// The file is plain text with one entry per line: `<day> <part> <input hash> <answer>`. Blank lines and lines starting
+// with # are ignored.
+type Ledger struct {
+	path    string
+	header  []string
+	answers map[ledgerKey]string // In decimal, so answers too big for an int can be recorded
+}
+
+type ledgerKey struct {
+	day       int
+	part      int
+	inputHash string
+}
+
+// Return a short, stable identifier for a puzzle input
+func HashInput(input string) string {
+	sum := sha256.Sum256([]byte(input))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// Load the ledger at path. A missing file is treated as an empty ledger, which will be created on Save.
+func LoadLedger(path string) (*Ledger, error) {
+	l := &Ledger{path: path, answers: make(map[ledgerKey]string)}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open ledger: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Keep the leading comment block so saving doesn't throw away any explanation at the top of the file
+		if strings.HasPrefix(line, "#") && len(l.answers) == 0 {
+			l.header = append(l.header, line)
+			continue
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, answer, err := parseLedgerLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+
+		l.answers[key] = answer
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read ledger: %w", err)
+	}
+
+	return l, nil
+}
+
+func parseLedgerLine(line string) (ledgerKey, string, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 {
+		return ledgerKey{}, "", fmt.Errorf("expected `<day> <part> <input hash> <answer>`, got %q", line)
+	}
+
+	numbers := make([]int, 0, 2)
+
+	for _, field := range fields[:2] {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return ledgerKey{}, "", fmt.Errorf("%q is not a number in %q", field, line)
+		}
+
+		numbers = append(numbers, n)
+	}
+
+	// Answers can be any size, but normalise them so they compare equal to AnswerString
+	answer, ok := new(big.Int).SetString(fields[3], 10)
+	if !ok {
+		return ledgerKey{}, "", fmt.Errorf("%q is not a number in %q", fields[3], line)
+	}
+
+	return ledgerKey{day: numbers[0], part: numbers[1], inputHash: fields[2]}, answer.String(), nil
+}
+
+// Return the accepted answer for the result's day, part and input, if one has been recorded.
+func (l *Ledger) Lookup(r Result) (string, bool) {
+	answer, ok := l.answers[ledgerKey{day: r.Day, part: r.Part, inputHash: r.InputHash}]
+
+	return answer, ok
+}
+
+// Record the result's answer as accepted, replacing any previous answer for the same day, part and input.
+func (l *Ledger) Record(r Result) {
+	l.answers[ledgerKey{day: r.Day, part: r.Part, inputHash: r.InputHash}] = r.AnswerString()
+}
+
+// Write the ledger back to the file it was loaded from, ordered by day, part then input hash so diffs stay small.
+func (l *Ledger) Save() error {
+	keys := slices.SortedFunc(maps.Keys(l.answers), func(a, b ledgerKey) int {
+		return cmp.Or(cmp.Compare(a.day, b.day), cmp.Compare(a.part, b.part), cmp.Compare(a.inputHash, b.inputHash))
+	})
+
+	var b strings.Builder
+
+	for _, line := range l.header {
+		b.WriteString(line + "\n")
+	}
+
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%d %d %s %s\n", k.day, k.part, k.inputHash, l.answers[k])
+	}
+
+	if err := os.WriteFile(l.path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("could not write ledger: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package aoc
+
+import (
+	"advent-of-code-2025/support"
+	"context"
+	"errors"
+	"io"
+)
+
+// Report whether the day can explain its answers.
+func (d Day) CanExplain() bool {
+	return d.explain != nil
+}
+
+// Load and parse the day's input from source, then write the solver's explanation of it to w. Every error is a
+// *SolveError.
+func (d Day) Explain(ctx context.Context, source support.InputSource, w io.Writer) error {
+	if d.explain == nil {
+		return &SolveError{Day: d.Number, Err: errors.New("nothing to explain")}
+	}
+
+	input, err := source.Load(d.Number)
+	if err != nil {
+		return &SolveError{Day: d.Number, Err: err}
+	}
+
+	parsed, err := d.Parse(input)
+	if err != nil {
+		return err
+	}
+
+	if err := d.explain(ctx, parsed, w); err != nil {
+		return &SolveError{Day: d.Number, Err: err}
+	}
+
+	return nil
+}
+
+// Report whether the day can solve straight from a reader.
+func (d Day) CanStream() bool {
+	return d.stream != nil
+}
+
+// Open the day's input from source and stream it through the solver, which writes its results to w as it goes. Every
+// error is a *SolveError.
+func (d Day) Stream(ctx context.Context, source support.InputSource, w io.Writer) error {
+	if d.stream == nil {
+		return &SolveError{Day: d.Number, Err: errors.New("can't stream")}
+	}
+
+	input, err := support.OpenInput(source, d.Number)
+	if err != nil {
+		return &SolveError{Day: d.Number, Err: err}
+	}
+	defer input.Close()
+
+	if err := d.stream(ctx, input, w); err != nil {
+		return &SolveError{Day: d.Number, Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package aoc
+
+import (
+	"advent-of-code-2025/support"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+type PoolOptions struct {
+	Workers int           // Maximum number of days solved at once; defaults to the number of CPUs
+	Timeout time.Duration // Per-day limit covering parsing and every part; zero means no limit
+
+	// Called with each day's results as soon as they're in, so in the order days finish rather than by day. Calls are
+	// never concurrent.
+	OnDay func([]Result)
+}
+
+// Run each of the days concurrently on a bounded pool of workers, each under its own timeout. Results are returned
+// ordered by day, then by part as given.
+//
+// A day that times out has its results returned straight away, but keeps its worker until its solver has actually
+// returned, so solvers that don't watch ctx can never push the number running past opts.Workers. Days still waiting
+// for a worker once ctx is done fail with its error without being started.
+func RunAll(ctx context.Context, days []Day, source support.InputSource, opts PoolOptions, parts ...int) []Result {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	// Each day writes to its own slot, so no locking is needed
+	resultsByDay := make([][]Result, len(days))
+
+	// Holds a token for each worker in use
+	busy := make(chan struct{}, workers)
+
+	var finished sync.WaitGroup
+
+	var reporting sync.Mutex
+	report := func(results []Result) {
+		if opts.OnDay != nil {
+			reporting.Lock()
+			defer reporting.Unlock()
+
+			opts.OnDay(results)
+		}
+	}
+
+	for i, day := range days {
+		select {
+		case busy <- struct{}{}:
+		case <-ctx.Done():
+			resultsByDay[i] = day.failed(source, parts, ctx.Err())
+			report(resultsByDay[i])
+
+			continue
+		}
+
+		finished.Add(1)
+
+		go func() {
+			var running sync.WaitGroup
+
+			resultsByDay[i] = runWithTimeout(ctx, day, source, opts.Timeout, &running, parts)
+			report(resultsByDay[i])
+			finished.Done()
+
+			running.Wait()
+			<-busy
+		}()
+	}
+
+	finished.Wait()
+
+	results := make([]Result, 0, len(days)*len(parts))
+	for _, r := range resultsByDay {
+		results = append(results, r...)
+	}
+
+	return results
+}
+
+func runWithTimeout(
+	ctx context.Context,
+	day Day,
+	source support.InputSource,
+	timeout time.Duration,
+	running *sync.WaitGroup,
+	parts []int,
+) []Result {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	results := day.run(ctx, source, running, parts...)
+
+	if timeout > 0 {
+		for i := range results {
+			var solveErr *SolveError
+			if errors.As(results[i].Err, &solveErr) && errors.Is(solveErr.Err, context.DeadlineExceeded) {
+				results[i].Err = &SolveError{
+					Day:  solveErr.Day,
+					Part: solveErr.Part,
+					Err:  fmt.Errorf("timed out after %v: %w", timeout, solveErr.Err),
+				}
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,233 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+)
+
+// A Solver describes how to solve a single day: Parse turns the raw puzzle input into whatever representation the day
+// works with, which is then handed to each of the parts.
+//
+// Malformed input should be reported as an error rather than a panic, ideally a support.ParseError pointing at the
+// offending line. Parts that can run for a long time should check ctx in their loops and return ctx.Err() once it is
+// done.
+type Solver[T any] struct {
+	Parse   func(input string) (T, error)
+	PartOne func(ctx context.Context, input T) (int, error)
+	PartTwo func(ctx context.Context, input T) (int, error)
+
+	// Optional arbitrary-precision versions of the parts, for answers that can outgrow an int. They're used instead of
+	// PartOne and PartTwo when solving under WithBigAnswers.
+	PartOneBig func(ctx context.Context, input T) (*big.Int, error)
+	PartTwoBig func(ctx context.Context, input T) (*big.Int, error)
+
+	// Optional commentary on how the answers come out of the given input, e.g. which parts of the input matter and
+	// why, written to w for `aoc explain`.
+	Explain func(ctx context.Context, input T, w io.Writer) error
+
+	// Optional way to solve straight from a reader, for inputs too big to load and parse all at once. It reads the raw
+	// input from r a bit at a time, writing results to w as it goes, for `aoc stream`.
+	Stream func(ctx context.Context, r io.Reader, w io.Writer) error
+
+	// Worked examples from the puzzle text, checked by verify
+	Examples []Example[T]
+}
+
+// An Example is a sample input quoted in the puzzle text along with the answers the puzzle gives for it.
+type Example[T any] struct {
+	Name  string
+	Input string
+
+	// Expected answers, which are always checked, even if zero, unless the matching No flag is set
+	PartOne int
+	PartTwo int
+
+	// Set when the puzzle doesn't give an answer for that part with this input, so there's nothing to check
+	NoPartOne bool
+	NoPartTwo bool
+
+	// Some puzzles use different parameters for their examples, e.g. fewer iterations. If set, Configure is applied to
+	// the parsed example before solving it.
+	Configure func(T) T
+}
+
+// A Day is a registered Solver with its parsed input type erased, so days with different representations can all live
+// in the same registry.
+type Day struct {
+	Number int
+
+	parse    func(string) (any, error)
+	parts    [2]func(context.Context, any) (int, error)
+	bigParts [2]func(context.Context, any) (*big.Int, error)   // Nil where the solver has no big version of the part
+	explain  func(context.Context, any, io.Writer) error       // Nil if the solver has nothing to explain
+	stream   func(context.Context, io.Reader, io.Writer) error // Nil if the solver can't stream
+	examples []example
+}
+
+type example struct {
+	name  string
+	input string
+	want  [2]int
+	check [2]bool // Whether each part has an answer in want
+	parse func(string) (any, error)
+}
+
+var registry = make(map[int]Day)
+
+// Register a solver for the given day. Intended to be called from each day package's init.
+func Register[T any](day int, solver Solver[T]) {
+	if _, exists := registry[day]; exists {
+		panic(fmt.Sprintf("Day %d registered more than once", day))
+	}
+
+	d := Day{
+		Number: day,
+		parse:  func(input string) (any, error) { return solver.Parse(input) },
+		parts: [2]func(context.Context, any) (int, error){
+			func(ctx context.Context, parsed any) (int, error) { return solver.PartOne(ctx, parsed.(T)) },
+			func(ctx context.Context, parsed any) (int, error) { return solver.PartTwo(ctx, parsed.(T)) },
+		},
+		stream: solver.Stream,
+	}
+
+	for i, part := range []func(context.Context, T) (*big.Int, error){solver.PartOneBig, solver.PartTwoBig} {
+		if part != nil {
+			d.bigParts[i] = func(ctx context.Context, parsed any) (*big.Int, error) { return part(ctx, parsed.(T)) }
+		}
+	}
+
+	if solver.Explain != nil {
+		d.explain = func(ctx context.Context, parsed any, w io.Writer) error {
+			return solver.Explain(ctx, parsed.(T), w)
+		}
+	}
+
+	for i, e := range solver.Examples {
+		name := e.Name
+		if name == "" {
+			name = fmt.Sprintf("example %d", i+1)
+		}
+
+		parse := d.parse
+		if e.Configure != nil {
+			parse = func(input string) (any, error) {
+				parsed, err := solver.Parse(input)
+				if err != nil {
+					return nil, err
+				}
+
+				return e.Configure(parsed), nil
+			}
+		}
+
+		d.examples = append(d.examples, example{
+			name:  name,
+			input: e.Input,
+			want:  [2]int{e.PartOne, e.PartTwo},
+			check: [2]bool{!e.NoPartOne, !e.NoPartTwo},
+			parse: parse,
+		})
+	}
+
+	registry[day] = d
+}
+
+// Return the registered day, if there is one.
+func Lookup(day int) (Day, bool) {
+	d, ok := registry[day]
+
+	return d, ok
+}
+
+// Return every registered day, ordered by day number.
+func Days() []Day {
+	days := make([]Day, 0, len(registry))
+
+	for _, d := range registry {
+		days = append(days, d)
+	}
+
+	slices.SortFunc(days, func(a, b Day) int { return a.Number - b.Number })
+
+	return days
+}
+
+// Parse the day's input, identifying the day in any error.
+func (d Day) Parse(input string) (any, error) {
+	parsed, err := d.parse(input)
+	if err != nil {
+		return nil, &SolveError{Day: d.Number, Err: err}
+	}
+
+	return parsed, nil
+}
+
+// Solve part 1 or 2 of the day against input previously returned by Parse, identifying the day and part in any error.
+func (d Day) Solve(ctx context.Context, part int, parsed any) (int, error) {
+	if part < 1 || part > len(d.parts) {
+		return 0, &SolveError{Day: d.Number, Part: part, Err: errors.New("no such part")}
+	}
+
+	answer, err := d.parts[part-1](ctx, parsed)
+	if err != nil {
+		return 0, &SolveError{Day: d.Number, Part: part, Err: err}
+	}
+
+	return answer, nil
+}
+
+// As Solve, but using the part's arbitrary-precision version if it has one. Parts without one are solved as normal.
+func (d Day) SolveBig(ctx context.Context, part int, parsed any) (*big.Int, error) {
+	if part < 1 || part > len(d.parts) || d.bigParts[part-1] == nil {
+		answer, err := d.Solve(ctx, part, parsed)
+		if err != nil {
+			return nil, err
+		}
+
+		return big.NewInt(int64(answer)), nil
+	}
+
+	answer, err := d.bigParts[part-1](ctx, parsed)
+	if err != nil {
+		return nil, &SolveError{Day: d.Number, Part: part, Err: err}
+	}
+
+	return answer, nil
+}
+
+type bigAnswersKey struct{}
+
+// Return a context under which Run solves with each part's arbitrary-precision version, where the day has one.
+func WithBigAnswers(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bigAnswersKey{}, true)
+}
+
+// Report whether ctx asks for arbitrary-precision answers.
+func BigAnswers(ctx context.Context) bool {
+	big, _ := ctx.Value(bigAnswersKey{}).(bool)
+
+	return big
+}
+
+// A SolveError is any failure while parsing or solving a day, identifying where it happened.
+type SolveError struct {
+	Day  int
+	Part int // Zero if the failure happened while loading or parsing the input
+	Err  error
+}
+
+func (e *SolveError) Error() string {
+	if e.Part == 0 {
+		return fmt.Sprintf("day %d: %v", e.Day, e.Err)
+	}
+
+	return fmt.Sprintf("day %d part %d: %v", e.Day, e.Part, e.Err)
+}
+
+func (e *SolveError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,190 @@
+package aoc
+
+import (
+	"advent-of-code-2025/support"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The outcome of solving one part of a day.
+type Result struct {
+	Day       int
+	Part      int
+	Answer    int
+	BigAnswer *big.Int      // Set instead of Answer when solved under WithBigAnswers
+	Duration  time.Duration // Time taken to solve the part, excluding loading and parsing the input
+	Input     string        // Where the input came from
+	InputHash string        // See HashInput
+	Err       error
+}
+
+// The answer in decimal, whichever of Answer and BigAnswer holds it.
+func (r Result) AnswerString() string {
+	if r.BigAnswer != nil {
+		return r.BigAnswer.String()
+	}
+
+	return strconv.Itoa(r.Answer)
+}
+
+func (r Result) MarshalJSON() ([]byte, error) {
+	record := struct {
+		Day       int          `json:"day"`
+		Part      int          `json:"part"`
+		Answer    *json.Number `json:"answer"`
+		Duration  int64        `json:"duration_ns"`
+		Input     string       `json:"input"`
+		InputHash string       `json:"input_hash,omitempty"`
+		Error     string       `json:"error,omitempty"`
+	}{
+		Day:       r.Day,
+		Part:      r.Part,
+		Duration:  r.Duration.Nanoseconds(),
+		Input:     r.Input,
+		InputHash: r.InputHash,
+	}
+
+	if r.Err != nil {
+		record.Error = r.Err.Error()
+	} else {
+		// A JSON number, however big, even though plenty of decoders will lose precision past 2^53
+		answer := json.Number(r.AnswerString())
+		record.Answer = &answer
+	}
+
+	return json.Marshal(record)
+}
+
+// Load the day's input from source, parse it once, then solve each of the requested parts in order. If the input can't
+// be loaded or parsed every result carries the error. Every error is a *SolveError, including any panic in the solver.
+//
+// Once ctx is done Run returns straight away, with ctx's error in any unfinished results, even if the solver hasn't
+// noticed yet. Under WithBigAnswers, answers are given in BigAnswer.
+func (d Day) Run(ctx context.Context, source support.InputSource, parts ...int) []Result {
+	return d.run(ctx, source, nil, parts...)
+}
+
+// As Run, but with running, if not nil, counting the parser and solvers until they've actually returned.
+func (d Day) run(ctx context.Context, source support.InputSource, running *sync.WaitGroup, parts ...int) []Result {
+	results := make([]Result, 0, len(parts))
+
+	// Fill in results for parts that can't be solved
+	fail := func(parts []int, err error) []Result {
+		return append(results, d.failed(source, parts, err)...)
+	}
+
+	input, err := source.Load(d.Number)
+	if err != nil {
+		return fail(parts, err)
+	}
+
+	parsed, err := awaitContext(ctx, running, func() (any, error) { return d.Parse(input) })
+	if err != nil {
+		return fail(parts, err)
+	}
+
+	inputHash := HashInput(input)
+
+	for i, part := range parts {
+		result := Result{Day: d.Number, Part: part, Input: source.Name(d.Number), InputHash: inputHash}
+		start := time.Now()
+
+		if BigAnswers(ctx) {
+			result.BigAnswer, err = awaitContext(ctx, running, func() (*big.Int, error) {
+				return d.SolveBig(ctx, part, parsed)
+			})
+		} else {
+			result.Answer, err = awaitContext(ctx, running, func() (int, error) { return d.Solve(ctx, part, parsed) })
+		}
+
+		result.Duration = time.Since(start)
+		result.Err = d.wrapError(part, err)
+		results = append(results, result)
+
+		// Parts are independent, so carry on after a failure unless it's because ctx is done
+		if err != nil && ctx.Err() != nil {
+			return fail(parts[i+1:], ctx.Err())
+		}
+	}
+
+	return results
+}
+
+// Results for parts that couldn't be solved, each carrying err.
+func (d Day) failed(source support.InputSource, parts []int, err error) []Result {
+	results := make([]Result, 0, len(parts))
+
+	for _, part := range parts {
+		results = append(results, Result{
+			Day:   d.Number,
+			Part:  part,
+			Input: source.Name(d.Number),
+			Err:   d.wrapError(part, err),
+		})
+	}
+
+	return results
+}
+
+// Make sure err identifies the day and part, if it doesn't already
+func (d Day) wrapError(part int, err error) error {
+	var solveErr *SolveError
+	if err == nil || errors.As(err, &solveErr) {
+		return err
+	}
+
+	return &SolveError{Day: d.Number, Part: part, Err: err}
+}
+
+// Run fn in the background, returning its result, or ctx's error if ctx is done first. fn is left to finish on its own
+// in that case, so should watch ctx itself to avoid wasting time; running, if not nil, counts it until it does. A panic
+// in fn is returned as an error.
+func awaitContext[T any](ctx context.Context, running *sync.WaitGroup, fn func() (T, error)) (T, error) {
+	type outcome struct {
+		result T
+		err    error
+	}
+
+	done := make(chan outcome, 1)
+
+	if running != nil {
+		running.Add(1)
+	}
+
+	go func() {
+		if running != nil {
+			defer running.Done()
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("panicked: %v", r)}
+			}
+		}()
+
+		result, err := fn()
+		done <- outcome{result: result, err: err}
+	}()
+
+	var zero T
+
+	select {
+	case o := <-done:
+		// The solver may have bailed out early because of ctx without saying so, in which case its result is
+		// meaningless
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		return o.result, o.err
+
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+)
+
+// The outcome of solving one part of an example and comparing it with the expected answer.
+type Check struct {
+	Day     int
+	Example string
+	Part    int
+	Want    int
+	Got     int
+	Err     error // Set if the example couldn't be parsed or solved
+}
+
+func (c Check) Passed() bool {
+	return c.Err == nil && c.Got == c.Want
+}
+
+func (c Check) String() string {
+	label := fmt.Sprintf("Day %d %s part %d", c.Day, c.Example, c.Part)
+
+	switch {
+	case c.Err != nil:
+		return fmt.Sprintf("FAIL %s: %v", label, c.Err)
+	case !c.Passed():
+		return fmt.Sprintf("FAIL %s: got %d, want %d (off by %d)", label, c.Got, c.Want, c.Got-c.Want)
+	default:
+		return fmt.Sprintf("ok   %s: %d", label, c.Got)
+	}
+}
+
+// Solve every example registered for the day, checking each part that has an expected answer.
+func (d Day) Verify() []Check {
+	checks := make([]Check, 0, len(d.examples)*len(d.parts))
+
+	for _, e := range d.examples {
+		for i, want := range e.want {
+			if !e.check[i] {
+				continue
+			}
+
+			check := Check{Day: d.Number, Example: e.name, Part: i + 1, Want: want}
+			check.Got, check.Err = d.solveExample(e, i)
+			checks = append(checks, check)
+		}
+	}
+
+	return checks
+}
+
+// Solve a single example part. Panics are turned into errors so one broken day doesn't stop the others being checked.
+func (d Day) solveExample(e example, part int) (int, error) {
+	return awaitContext(context.Background(), nil, func() (int, error) {
+		parsed, err := e.parse(e.input)
+		if err != nil {
+			return 0, err
+		}
+
+		return d.parts[part](context.Background(), parsed)
+	})
+}
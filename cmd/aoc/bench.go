@@ -0,0 +1,82 @@
+package main
+
+import (
+	"advent-of-code-2025/aoc"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// Time each phase of the selected days over repeated runs, printing a table or JSON
+func bench(args []string) {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	runs := flags.Int("runs", 10, "number of times to parse and solve each day")
+	asJSON := flags.Bool("json", false, "print results as JSON instead of a table")
+	input := addInputFlags(flags)
+
+	days := selectDays(parseArgs(flags, args))
+	source := input.source(len(days))
+
+	if *runs < 1 {
+		fail("--runs must be at least 1, got %d", *runs)
+	}
+
+	benchmarks := make([]aoc.Benchmark, 0, len(days))
+	failures := 0
+
+	// A day that fails still gets its row, so one bad day doesn't hide the others' results
+	for _, day := range days {
+		b, err := day.Benchmark(source, *runs)
+		if err != nil {
+			b = aoc.Benchmark{Day: day.Number, Error: err.Error()}
+			failures++
+		}
+
+		benchmarks = append(benchmarks, b)
+	}
+
+	printBenchmarks(benchmarks, *asJSON)
+
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
+
+// Print the benchmarks as a table, or as JSON if asJSON is set
+func printBenchmarks(benchmarks []aoc.Benchmark, asJSON bool) {
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(benchmarks); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "day\tphase\truns\tmin\tmedian\tp95\tallocs/op\tbytes/op\t")
+
+	for _, b := range benchmarks {
+		// The error goes after the last tab so it doesn't stretch the columns
+		if b.Error != "" {
+			fmt.Fprintf(table, "%d\tfailed\t\t\t\t\t\t\t%s\n", b.Day, b.Error)
+			continue
+		}
+
+		for _, p := range b.Phases {
+			fmt.Fprintf(
+				table,
+				"%d\t%s\t%d\t%v\t%v\t%v\t%d\t%d\t\n",
+				b.Day, p.Phase, p.Runs, p.Min, p.Median, p.P95, p.AllocsPerOp, p.BytesPerOp,
+			)
+		}
+	}
+
+	table.Flush()
+}
@@ -0,0 +1,15 @@
+package main
+
+// Each day registers its solver with the aoc package when imported
+import (
+	_ "advent-of-code-2025/day1"
+	_ "advent-of-code-2025/day10"
+	_ "advent-of-code-2025/day2"
+	_ "advent-of-code-2025/day3"
+	_ "advent-of-code-2025/day4"
+	_ "advent-of-code-2025/day5"
+	_ "advent-of-code-2025/day6"
+	_ "advent-of-code-2025/day7"
+	_ "advent-of-code-2025/day8"
+	_ "advent-of-code-2025/day9"
+)
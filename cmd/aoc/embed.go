@@ -0,0 +1,12 @@
+//go:build embedinputs
+
+package main
+
+import "embed"
+
+const hasEmbeddedInputs = true
+
+// Inputs placed in cmd/aoc/inputs/ as day1.txt, day2.txt etc.
+//
+//go:embed inputs
+var embeddedInputs embed.FS
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+const usage = `Usage:
+  aoc run <day|all> [--part 1|2] [--format text|json|ndjson] [--input <path>|-] [--embedded]
+          [--ledger <path>] [--record] [--jobs N] [--timeout <duration>] [--big]
+  aoc verify [day|all]
+  aoc bench <day|all> [--runs N] [--json] [--input <path>|-] [--embedded]
+  aoc explain <day|all> [--input <path>|-] [--embedded]
+  aoc stream <day|all> [--input <path>|-] [--embedded]
+
+Input is read from the first of:
+  --input <path>   a file, or - for stdin; {day} in the path is replaced with the day number
+  --embedded       inputs baked into the binary with -tags embedinputs
+  $AOC_INPUT       as --input, e.g. AOC_INPUT=/ci/inputs/day{day}.txt
+  day{day}/input.txt relative to the working directory
+
+Days are solved concurrently by up to --jobs workers, each day limited to --timeout
+(e.g. 30s; 0 for no limit). Interrupting the run cancels any days still in progress.
+
+explain describes how a day's answers come out of its input, and stream solves straight
+from the input as it's read without loading it all first, on days that support them.
+
+--big solves with arbitrary-precision integers on days that support it, for inputs
+whose answers don't fit in 64 bits.
+
+Every answer is compared with the ledger of accepted answers for the same input, and
+any mismatch fails the run. --record accepts the answers from this run into the ledger.
+`
+
+// Environment variable consulted for an input path when neither --input nor --embedded is given
+const inputEnvVar = "AOC_INPUT"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "run":
+		run(os.Args[2:])
+	case "verify":
+		verify(os.Args[2:])
+	case "bench":
+		bench(os.Args[2:])
+	case "explain":
+		explain(os.Args[2:])
+	case "stream":
+		stream(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+func run(args []string) {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	part := flags.Int("part", 0, "only solve the given part (1 or 2); both are solved by default")
+	format := flags.String("format", "text", "output format: text, json (one array) or ndjson (one record per line)")
+	input := addInputFlags(flags)
+	ledgerPath := flags.String("ledger", "answers.ledger", "ledger of accepted answers to check against")
+	record := flags.Bool("record", false, "record this run's answers in the ledger as accepted")
+	jobs := flags.Int("jobs", runtime.NumCPU(), "maximum number of days to solve at once")
+	timeout := flags.Duration("timeout", time.Minute, "time limit for each day, or 0 for none")
+	bigAnswers := flags.Bool("big", false, "use arbitrary-precision integers where a day supports them")
+
+	days := selectDays(parseArgs(flags, args))
+	source := input.source(len(days))
+
+	parts := []int{1, 2}
+	if *part != 0 {
+		if *part != 1 && *part != 2 {
+			fail("--part must be 1 or 2, got %d", *part)
+		}
+
+		parts = []int{*part}
+	}
+
+	output, err := newResultWriter(*format, os.Stdout)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ledger, err := aoc.LoadLedger(*ledgerPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *bigAnswers {
+		ctx = aoc.WithBigAnswers(ctx)
+	}
+
+	failures := 0
+	mismatches := 0
+
+	handle := func(result aoc.Result) {
+		if err := output.Write(result); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		if result.Err != nil {
+			failures++
+			return
+		}
+
+		if *record {
+			ledger.Record(result)
+		} else if accepted, ok := ledger.Lookup(result); ok && accepted != result.AnswerString() {
+			fmt.Fprintf(
+				os.Stderr,
+				"!!! MISMATCH Day %d part %d: got %s but the accepted answer for input %s is %s\n",
+				result.Day, result.Part, result.AnswerString(), result.InputHash, accepted,
+			)
+			mismatches++
+		}
+	}
+
+	opts := aoc.PoolOptions{Workers: *jobs, Timeout: *timeout}
+
+	// Each ndjson record stands on its own, so they're written as each day finishes; the other formats wait so their
+	// results come out ordered by day
+	streaming := *format == "ndjson"
+	if streaming {
+		opts.OnDay = func(results []aoc.Result) {
+			for _, result := range results {
+				handle(result)
+			}
+		}
+	}
+
+	results := aoc.RunAll(ctx, days, source, opts, parts...)
+
+	if !streaming {
+		for _, result := range results {
+			handle(result)
+		}
+	}
+
+	if err := output.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *record {
+		if err := ledger.Save(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if failures > 0 || mismatches > 0 {
+		os.Exit(1)
+	}
+}
+
+// Check every registered example against its expected answers, exiting non-zero if any fail
+func verify(args []string) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+
+	positional := parseArgs(flags, args)
+	if len(positional) == 0 {
+		positional = []string{"all"}
+	}
+
+	failures := 0
+
+	for _, day := range selectDays(positional) {
+		for _, check := range day.Verify() {
+			fmt.Println(check)
+
+			if !check.Passed() {
+				failures++
+			}
+		}
+	}
+
+	if failures > 0 {
+		fmt.Fprintf(os.Stderr, "%d example(s) failed\n", failures)
+		os.Exit(1)
+	}
+}
+
+// Parse flags that may appear anywhere among the positional arguments, e.g. `run 7 --part 2`, returning the positional
+// arguments. The standard library stops parsing flags at the first positional argument.
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	positional := make([]string, 0)
+
+	for {
+		// ExitOnError means Parse never returns an error
+		_ = flags.Parse(args)
+
+		if flags.NArg() == 0 {
+			return positional
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// Resolve a single positional argument of either a day number or "all" to the registered days
+func selectDays(args []string) []aoc.Day {
+	if len(args) != 1 {
+		fail("Expected exactly one day number or \"all\"")
+	}
+
+	if args[0] == "all" {
+		return aoc.Days()
+	}
+
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		fail("Expected a day number or \"all\", got %q", args[0])
+	}
+
+	day, ok := aoc.Lookup(number)
+	if !ok {
+		fail("No solver registered for day %d", number)
+	}
+
+	return []aoc.Day{day}
+}
+
+type inputFlags struct {
+	path     *string
+	embedded *bool
+}
+
+func addInputFlags(flags *flag.FlagSet) inputFlags {
+	return inputFlags{
+		path:     flags.String("input", "", "read input from this path, or - for stdin"),
+		embedded: flags.Bool("embedded", false, "read input embedded in the binary"),
+	}
+}
+
+// Decide where input comes from; see usage for the order of precedence
+func (f inputFlags) source(dayCount int) support.InputSource {
+	inputPath := *f.path
+
+	// The environment variable stands in for --input, so it's checked the same way, stdin and all. Either flag given
+	// explicitly wins over it.
+	if inputPath == "" && !*f.embedded {
+		inputPath = os.Getenv(inputEnvVar)
+	}
+
+	switch {
+	case inputPath == "-":
+		if dayCount > 1 {
+			fail("Cannot read input for more than one day from stdin")
+		}
+
+		return support.StdinInput()
+
+	case inputPath != "":
+		return support.FileInput(inputPath)
+
+	case *f.embedded:
+		if !hasEmbeddedInputs {
+			fail("This binary was built without embedded inputs; rebuild with -tags embedinputs")
+		}
+
+		return support.FSInput{FS: embeddedInputs, Path: "inputs/day" + support.DayPlaceholder + ".txt"}
+
+	default:
+		return support.DayDirInput(".")
+	}
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n\n%s", append(args, usage)...)
+	os.Exit(2)
+}
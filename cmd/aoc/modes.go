@@ -0,0 +1,65 @@
+package main
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// Write each selected day's explanation of its answers for the input
+func explain(args []string) {
+	runMode("explain", args, aoc.Day.CanExplain, aoc.Day.Explain)
+}
+
+// Solve the selected day straight from its input as it's read, writing results as they come
+func stream(args []string) {
+	runMode("stream", args, aoc.Day.CanStream, aoc.Day.Stream)
+}
+
+// Run a mode that only some days support against each selected day's input, writing its output to stdout. With "all",
+// days that don't support the mode are skipped.
+func runMode(
+	name string,
+	args []string,
+	supported func(aoc.Day) bool,
+	mode func(aoc.Day, context.Context, support.InputSource, io.Writer) error,
+) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	input := addInputFlags(flags)
+
+	positional := parseArgs(flags, args)
+	days := selectDays(positional)
+	source := input.source(len(days))
+
+	if positional[0] != "all" && !supported(days[0]) {
+		fail("Day %d doesn't support %s", days[0].Number, name)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	output := bufio.NewWriter(os.Stdout)
+	defer output.Flush()
+
+	for _, day := range days {
+		if !supported(day) {
+			continue
+		}
+
+		if len(days) > 1 {
+			fmt.Fprintf(output, "Day %d:\n", day.Number)
+		}
+
+		if err := mode(day, ctx, source, output); err != nil {
+			output.Flush()
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
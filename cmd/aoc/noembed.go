@@ -0,0 +1,9 @@
+//go:build !embedinputs
+
+package main
+
+import "embed"
+
+const hasEmbeddedInputs = false
+
+var embeddedInputs embed.FS
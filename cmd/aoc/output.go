@@ -0,0 +1,91 @@
+package main
+
+import (
+	"advent-of-code-2025/aoc"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Writes results as they are produced in one of the supported --format styles
+type resultWriter interface {
+	Write(aoc.Result) error
+	// Flush anything buffered; must be called once all results are written
+	Close() error
+}
+
+func newResultWriter(format string, w io.Writer) (resultWriter, error) {
+	switch format {
+	case "text":
+		return &textWriter{w: w}, nil
+	case "json":
+		return &jsonWriter{w: w}, nil
+	case "ndjson":
+		return ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q: expected text, json or ndjson", format)
+	}
+}
+
+// Human readable lines; errors go to stderr so stdout only ever holds answers
+type textWriter struct {
+	w io.Writer
+	// A parse error fails every part the same way, so only report it once
+	lastErr string
+}
+
+func (t *textWriter) Write(r aoc.Result) error {
+	if r.Err != nil {
+		if r.Err.Error() == t.lastErr {
+			return nil
+		}
+
+		t.lastErr = r.Err.Error()
+		_, err := fmt.Fprintln(os.Stderr, r.Err)
+		return err
+	}
+
+	_, err := fmt.Fprintf(t.w, "Day %d part %d: %s\n", r.Day, r.Part, r.AnswerString())
+	return err
+}
+
+func (t *textWriter) Close() error {
+	return nil
+}
+
+// A single JSON array of every result, written once all are in
+type jsonWriter struct {
+	w       io.Writer
+	results []aoc.Result
+}
+
+func (j *jsonWriter) Write(r aoc.Result) error {
+	j.results = append(j.results, r)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	encoder := json.NewEncoder(j.w)
+	encoder.SetIndent("", "  ")
+
+	// Encode an empty array rather than null if there were no results
+	if j.results == nil {
+		j.results = []aoc.Result{}
+	}
+
+	return encoder.Encode(j.results)
+}
+
+// One JSON object per line, written as each day finishes, so not necessarily ordered by day
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n ndjsonWriter) Write(r aoc.Result) error {
+	return n.encoder.Encode(r)
+}
+
+func (n ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package day1
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"context"
+	_ "embed"
+	"errors"
+	"strconv"
+)
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(1, aoc.Solver[[]int]{
+		Parse:   func(input string) ([]int, error) { return support.ParseLines(support.Lines(input), parseMove) },
+		PartOne: func(_ context.Context, moves []int) (int, error) { return partOne(moves), nil },
+		PartTwo: func(_ context.Context, moves []int) (int, error) { return partTwo(moves), nil },
+		Examples: []aoc.Example[[]int]{
+			{Input: exampleInput, PartOne: 3, PartTwo: 6},
+		},
+	})
+}
+
+// Given a move e.g. "L68", return the amount to rotate the dial by; negative for left.
+func parseMove(line string) (int, error) {
+	if len(line) < 2 {
+		return 0, errors.New("expected a direction followed by a distance")
+	}
+
+	rotateBy, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return 0, errors.New("distance is not a number")
+	}
+
+	switch line[:1] {
+	case "L":
+		return -rotateBy, nil
+	case "R":
+		return rotateBy, nil
+	default:
+		return 0, errors.New("direction must be L or R")
+	}
+}
+
+func partOne(moves []int) int {
+	dial := 50
+	zeroes := 0
+
+	for _, rotateBy := range moves {
+		dial += rotateBy % 100
+
+		// Normalise back to 0...99
+		if dial < 0 {
+			dial += 100
+		} else if dial > 99 {
+			dial -= 100
+		}
+
+		if dial == 0 {
+			zeroes += 1
+		}
+	}
+
+	return zeroes
+}
+
+func partTwo(moves []int) int {
+	dial := 50
+	zeroes := 0
+
+	for _, rotateBy := range moves {
+		dialStart := dial
+
+		dial += rotateBy % 100
+
+		// Calculate the number of complete rotations
+		zeroes += support.AbsInt(rotateBy / 100)
+
+		// Normalise back to 0...99, counting any partial rotations that crossed 0
+		if dial < 0 {
+			dial += 100
+			// e.g. L5 from 0 would not count as crossing zero
+			if dialStart > 0 {
+				zeroes += 1
+			}
+		} else if dial > 99 {
+			dial -= 100
+			zeroes += 1
+		} else if dial == 0 {
+			zeroes += 1
+		}
+	}
+
+	return zeroes
+}
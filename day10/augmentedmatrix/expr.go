@@ -12,7 +12,8 @@ type linearExpr struct {
 	freeVarCoeffs map[int]int // Map of matrix column index onto the coefficient
 }
 
-func newLinearExpr(row AugmentedMatrixRow, pivotCol int, pivotExpressions map[int]linearExpr) linearExpr {
+// Errors if scaling the row up to keep everything integral overflows.
+func newLinearExpr(row AugmentedMatrixRow, pivotCol int, pivotExpressions map[int]linearExpr) (linearExpr, error) {
 	freeVarCoeffs := make(map[int]int)
 	total := row.total
 
@@ -20,6 +21,7 @@ func newLinearExpr(row AugmentedMatrixRow, pivotCol int, pivotExpressions map[in
 	copy(coefficients, row.coefficients)
 
 	scaleFactor := 1
+	var err error
 
 	// To account for the fact that pivot expressions might have coefficients, but we want to keep everything as an
 	// integer, we need to scale up every coefficient by the least common multiple of all pivot expression coefficients
@@ -28,14 +30,20 @@ func newLinearExpr(row AugmentedMatrixRow, pivotCol int, pivotExpressions map[in
 			continue
 		}
 
-		scaleFactor = support.Lcm(scaleFactor, v.coefficient)
+		if scaleFactor, err = support.Lcm(scaleFactor, v.coefficient); err != nil {
+			return linearExpr{}, err
+		}
 	}
 
 	for i := pivotCol; i < len(coefficients); i++ {
-		coefficients[i] *= scaleFactor
+		if coefficients[i], err = support.MulChecked(coefficients[i], scaleFactor); err != nil {
+			return linearExpr{}, err
+		}
 	}
 
-	total *= scaleFactor
+	if total, err = support.MulChecked(total, scaleFactor); err != nil {
+		return linearExpr{}, err
+	}
 
 	for i := pivotCol + 1; i < len(coefficients); i++ {
 		if coefficients[i] == 0 {
@@ -52,27 +60,52 @@ func newLinearExpr(row AugmentedMatrixRow, pivotCol int, pivotExpressions map[in
 			// f + 2e = 234
 			// We scale to 3f + 6e = 702, we are then subsituting "two 3e's" so we need to multiply the values in expr
 			// by 6 / 3 = 2
-			total -= expr.constant * (coefficients[i] / expr.coefficient)
+			multiple := coefficients[i] / expr.coefficient
+
+			substituted, err := support.MulChecked(expr.constant, multiple)
+			if err != nil {
+				return linearExpr{}, err
+			}
+
+			if total, err = support.SubChecked(total, substituted); err != nil {
+				return linearExpr{}, err
+			}
 
 			for j, e := range expr.freeVarCoeffs {
-				coefficients[j] += e * (coefficients[i] / expr.coefficient)
+				substituted, err := support.MulChecked(e, multiple)
+				if err != nil {
+					return linearExpr{}, err
+				}
+
+				if coefficients[j], err = support.AddChecked(coefficients[j], substituted); err != nil {
+					return linearExpr{}, err
+				}
 			}
 
 			continue
 		}
 
 		// * -1 because we need to subtract it from the RHS
-		freeVarCoeffs[i] = coefficients[i] * -1
+		if freeVarCoeffs[i], err = support.MulChecked(coefficients[i], -1); err != nil {
+			return linearExpr{}, err
+		}
 	}
 
 	// When we come to derive limits for free variables later, life is much easier if we've pre-normalised all the
 	// equations to have a positive coefficient on the pivot variable.
 	if coefficients[pivotCol] < 0 {
-		coefficients[pivotCol] *= -1
-		total *= -1
+		if coefficients[pivotCol], err = support.MulChecked(coefficients[pivotCol], -1); err != nil {
+			return linearExpr{}, err
+		}
+
+		if total, err = support.MulChecked(total, -1); err != nil {
+			return linearExpr{}, err
+		}
 
 		for k := range freeVarCoeffs {
-			freeVarCoeffs[k] *= -1
+			if freeVarCoeffs[k], err = support.MulChecked(freeVarCoeffs[k], -1); err != nil {
+				return linearExpr{}, err
+			}
 		}
 	}
 
@@ -80,10 +113,12 @@ func newLinearExpr(row AugmentedMatrixRow, pivotCol int, pivotExpressions map[in
 		coefficient:   coefficients[pivotCol],
 		constant:      total,
 		freeVarCoeffs: freeVarCoeffs,
-	}
+	}, nil
 }
 
-func evaluateExpressions(expressions map[int]linearExpr, freeVarValues map[int]int) map[int]int {
+// Work out every pivot variable from the free variable values, returning nil if they don't give a whole, non-negative
+// number of presses for every variable. Errors if the arithmetic overflows.
+func evaluateExpressions(expressions map[int]linearExpr, freeVarValues map[int]int) (map[int]int, error) {
 	results := make(map[int]int)
 	for k, v := range freeVarValues {
 		results[k] = v
@@ -93,23 +128,26 @@ func evaluateExpressions(expressions map[int]linearExpr, freeVarValues map[int]i
 		result := expr.constant
 
 		for col, coeff := range expr.freeVarCoeffs {
-			result += coeff * freeVarValues[col]
+			var err error
+			if result, err = mulAdd(result, coeff, freeVarValues[col]); err != nil {
+				return nil, err
+			}
 		}
 
 		// We know all solutions must be integers
 		if result%expr.coefficient != 0 {
-			return nil
+			return nil, nil
 		}
 
 		result /= expr.coefficient
 
 		// We know these free variable values cannot be valid if any expression evaluates to < 0
 		if result < 0 {
-			return nil
+			return nil, nil
 		}
 
 		results[col] = result
 	}
 
-	return results
+	return results, nil
 }
@@ -2,11 +2,13 @@ package augmentedmatrix
 
 import (
 	"advent-of-code-2025/support"
+	"context"
 	"maps"
 	"slices"
 )
 
-func enumerateFreeVariableCombinations(lims map[int]limits) []map[int]int {
+// Stops early, returning whatever it has so far, once ctx is done; the sheer number of combinations can be huge.
+func enumerateFreeVariableCombinations(ctx context.Context, lims map[int]limits) []map[int]int {
 	// This is pretty fuckin gross but at this point I don't really care.
 	// If there's no free variables we can return a slice just containing a single nil map: there is only one solution
 	// and when we come to evaluate each expression we will end up ignoring this anyway. This still allows us to
@@ -32,6 +34,10 @@ func enumerateFreeVariableCombinations(lims map[int]limits) []map[int]int {
 		variable := keys[key]
 
 		for _, v := range values[variable] {
+			if ctx.Err() != nil {
+				return
+			}
+
 			combination[variable] = v
 
 			if key == len(keys)-1 {
@@ -62,3 +68,23 @@ func coefficientsAllZero(c []int) bool {
 
 	return true
 }
+
+// Return acc + a*b, or an error if either step overflows.
+func mulAdd(acc, a, b int) (int, error) {
+	product, err := support.MulChecked(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return support.AddChecked(acc, product)
+}
+
+// Return a/b rounded up, for positive b.
+func ceilDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a > 0 {
+		q++
+	}
+
+	return q
+}
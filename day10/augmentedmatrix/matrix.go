@@ -2,15 +2,82 @@ package augmentedmatrix
 
 import (
 	"advent-of-code-2025/support"
+	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"math"
+	"math/big"
+	"slices"
 	"strings"
 )
 
-func NewRefAugmentedMatrix(coefficients [][]int, totals []int) AugmentedMatrix {
+// Build the augmented matrix for the given switch coefficients and totals, in row echelon form. Errors if eliminating
+// rows overflows an int; NewRefAugmentedMatrixBig can cope with that.
+func NewRefAugmentedMatrix(coefficients [][]int, totals []int) (AugmentedMatrix, error) {
+	matrix := newAugmentedMatrix(coefficients, totals)
+
+	if err := matrix.toRowEchelonForm(); err != nil {
+		return AugmentedMatrix{}, err
+	}
+
+	return matrix, nil
+}
+
+// As NewRefAugmentedMatrix, but eliminates rows with arbitrary-precision integers, dividing each row through by the gcd
+// of its entries as it goes. Only the final reduced rows need to fit in an int.
+func NewRefAugmentedMatrixBig(coefficients [][]int, totals []int) (AugmentedMatrix, error) {
+	matrix := newAugmentedMatrix(coefficients, totals)
+
+	// Each row with its total on the end
+	rows := make([][]*big.Int, len(matrix.rows))
+	for i, row := range matrix.rows {
+		rows[i] = support.Map(append(slices.Clone(row.coefficients), row.total), func(v int) *big.Int {
+			return big.NewInt(int64(v))
+		})
+	}
+
+	err := reduceToRowEchelonForm(
+		len(rows),
+		matrix.cols,
+		func(row, col int) bool { return rows[row][col].Sign() == 0 },
+		func(i, j int) { rows[i], rows[j] = rows[j], rows[i] },
+		func(pivotRow, pivotCol int) error {
+			eliminateBigRows(rows, pivotRow, pivotCol)
+			return nil
+		},
+	)
+	if err != nil {
+		return AugmentedMatrix{}, err
+	}
+
+	for i, row := range rows {
+		for j, v := range row {
+			if !v.IsInt64() || int64(int(v.Int64())) != v.Int64() {
+				return AugmentedMatrix{}, fmt.Errorf(
+					"row %d is left with %v after reducing: %w", i+1, v, support.ErrOverflow,
+				)
+			}
+
+			if j == len(row)-1 {
+				matrix.rows[i].total = int(v.Int64())
+			} else {
+				matrix.rows[i].coefficients[j] = int(v.Int64())
+			}
+		}
+	}
+
+	if err := matrix.removeEmptyRows(); err != nil {
+		return AugmentedMatrix{}, err
+	}
+
+	return matrix, nil
+}
+
+func newAugmentedMatrix(coefficients [][]int, totals []int) AugmentedMatrix {
 	matrix := AugmentedMatrix{
 		rows: make([]AugmentedMatrixRow, len(totals)),
+		cols: len(coefficients),
 	}
 
 	for i := range len(totals) {
@@ -25,8 +92,6 @@ func NewRefAugmentedMatrix(coefficients [][]int, totals []int) AugmentedMatrix {
 		}
 	}
 
-	matrix.toRowEchelonForm()
-
 	return matrix
 }
 
@@ -37,6 +102,7 @@ type AugmentedMatrixRow struct {
 
 type AugmentedMatrix struct {
 	rows []AugmentedMatrixRow
+	cols int // The number of variables, kept apart from the rows as reducing can remove every one of them
 }
 
 func (m *AugmentedMatrix) Print() {
@@ -75,26 +141,43 @@ func (m *AugmentedMatrix) Print() {
 // |  0  0 -1  0 ||  -5 |
 //
 // through a combination of reordering rows and row multiplication/addition/subtraction, and eliminating all-zero rows.
-func (m *AugmentedMatrix) toRowEchelonForm() {
+func (m *AugmentedMatrix) toRowEchelonForm() error {
+	err := reduceToRowEchelonForm(
+		len(m.rows),
+		m.cols,
+		func(row, col int) bool { return m.rows[row].coefficients[col] == 0 },
+		func(i, j int) { m.rows[i], m.rows[j] = m.rows[j], m.rows[i] },
+		m.eliminateRows,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Remove rows reduced to all zeroes, as they do not contribute to our solution
+	return m.removeEmptyRows()
+}
+
+// Walk the pivots of a matrix with the given number of rows and coefficient columns, swapping each pivot row into place
+// and eliminating below it. Kept apart from the values themselves so int and big.Int matrices can share it.
+func reduceToRowEchelonForm(
+	rows, cols int,
+	isZero func(row, col int) bool,
+	swap func(i, j int),
+	eliminate func(pivotRow, pivotCol int) error,
+) error {
 	pivotCol := 0
 	currRow := 0
 
-	for {
-		if currRow >= len(m.rows) || pivotCol >= len(m.rows[0].coefficients) {
-			break
-		}
-
+	for currRow < rows && pivotCol < cols {
 		// First try to locate a pivot value
 		pivotFound := false
-		for i := currRow; i < len(m.rows); i++ {
-			if m.rows[i].coefficients[pivotCol] != 0 {
+		for i := currRow; i < rows; i++ {
+			if !isZero(i, pivotCol) {
 				pivotFound = true
 
 				// Swap the rows if necessary to put the pivot in the right place
 				if i != currRow {
-					tmp := m.rows[currRow]
-					m.rows[currRow] = m.rows[i]
-					m.rows[i] = tmp
+					swap(currRow, i)
 				}
 
 				break
@@ -103,7 +186,10 @@ func (m *AugmentedMatrix) toRowEchelonForm() {
 
 		// Then eliminate non-zero values below it
 		if pivotFound {
-			m.eliminateRows(currRow, pivotCol)
+			if err := eliminate(currRow, pivotCol); err != nil {
+				return err
+			}
+
 			// If we didn't find a pivot for a given column we need to try the next pivot on the same row
 			currRow++
 		}
@@ -111,11 +197,10 @@ func (m *AugmentedMatrix) toRowEchelonForm() {
 		pivotCol++
 	}
 
-	// Remove rows reduced to all zeroes, as they do not contribute to our solution
-	m.removeEmptyRows()
+	return nil
 }
 
-func (m *AugmentedMatrix) eliminateRows(pivotRow, pivotCol int) {
+func (m *AugmentedMatrix) eliminateRows(pivotRow, pivotCol int) error {
 	for i := pivotRow + 1; i < len(m.rows); i++ {
 		if m.rows[i].coefficients[pivotCol] != 0 {
 			// If our pivot row is P and the target row to eliminate is R, then we can reduce R's value in the
@@ -126,16 +211,72 @@ func (m *AugmentedMatrix) eliminateRows(pivotRow, pivotCol int) {
 			q := m.rows[i].coefficients[pivotCol]
 
 			for j := pivotCol; j < len(m.rows[i].coefficients); j++ {
-				m.rows[i].coefficients[j] = (m.rows[i].coefficients[j] * p) - (m.rows[pivotRow].coefficients[j] * q)
+				coefficient, err := scaleAndSubtract(m.rows[i].coefficients[j], p, m.rows[pivotRow].coefficients[j], q)
+				if err != nil {
+					return err
+				}
+
+				m.rows[i].coefficients[j] = coefficient
 			}
 
 			// We then also must scale the total by the same factor
-			m.rows[i].total = (m.rows[i].total * p) - (m.rows[pivotRow].total * q)
+			total, err := scaleAndSubtract(m.rows[i].total, p, m.rows[pivotRow].total, q)
+			if err != nil {
+				return err
+			}
+
+			m.rows[i].total = total
+		}
+	}
+
+	return nil
+}
+
+// Return r*p - s*q, or an error if any step overflows.
+func scaleAndSubtract(r, p, s, q int) (int, error) {
+	scaled, err := support.MulChecked(r, p)
+	if err != nil {
+		return 0, err
+	}
+
+	subtracted, err := support.MulChecked(s, q)
+	if err != nil {
+		return 0, err
+	}
+
+	return support.SubChecked(scaled, subtracted)
+}
+
+// As eliminateRows, on rows with their totals on the end. Each eliminated row is divided by the gcd of its entries, so
+// the numbers only grow as much as they have to.
+func eliminateBigRows(rows [][]*big.Int, pivotRow, pivotCol int) {
+	p := rows[pivotRow][pivotCol]
+
+	for i := pivotRow + 1; i < len(rows); i++ {
+		q := new(big.Int).Set(rows[i][pivotCol])
+		if q.Sign() == 0 {
+			continue
+		}
+
+		gcd := new(big.Int)
+
+		for j := pivotCol; j < len(rows[i]); j++ {
+			scaled := new(big.Int).Mul(rows[i][j], p)
+			rows[i][j] = scaled.Sub(scaled, new(big.Int).Mul(rows[pivotRow][j], q))
+			gcd.GCD(nil, nil, gcd, new(big.Int).Abs(rows[i][j]))
+		}
+
+		if gcd.Sign() != 0 && gcd.Cmp(big.NewInt(1)) != 0 {
+			for j := pivotCol; j < len(rows[i]); j++ {
+				rows[i][j].Quo(rows[i][j], gcd)
+			}
 		}
 	}
 }
 
-func (m *AugmentedMatrix) removeEmptyRows() {
+// Remove rows whose coefficients are all zero. Errors if any of them has a non-zero total, as nothing can add up to it
+// and the matrix has no solution.
+func (m *AugmentedMatrix) removeEmptyRows() error {
 	i := 0
 	for {
 		if i >= len(m.rows) {
@@ -143,6 +284,10 @@ func (m *AugmentedMatrix) removeEmptyRows() {
 		}
 
 		if coefficientsAllZero(m.rows[i].coefficients) {
+			if m.rows[i].total != 0 {
+				return fmt.Errorf("the equations reduce to 0 = %d, so no presses can solve them", m.rows[i].total)
+			}
+
 			for j := i; j < len(m.rows)-1; j++ {
 				m.rows[j] = m.rows[j+1]
 			}
@@ -152,10 +297,13 @@ func (m *AugmentedMatrix) removeEmptyRows() {
 			i++
 		}
 	}
+
+	return nil
 }
 
-// Return the smallest possible sum of the set of variables solving the matrix
-func (m *AugmentedMatrix) Solve() int {
+// Return the smallest possible sum of the set of variables solving the matrix. Gives up with ctx's error once it's
+// done.
+func (m *AugmentedMatrix) Solve(ctx context.Context) (int, error) {
 	// Create a maps to store the pivot column for each row, the expressions for each pivot variable in terms of free
 	// variables, and any known fixed values.
 	pivotMap := make(map[int]int)
@@ -174,25 +322,52 @@ func (m *AugmentedMatrix) Solve() int {
 	// Then work from the bottom up, identifying invariants and denoting each dependent variable in terms of frees
 	for i := len(m.rows) - 1; i >= 0; i-- {
 		// Express the pivot value in terms of the free variables
-		pivotExpressions[pivotMap[i]] = newLinearExpr(m.rows[i], pivotMap[i], pivotExpressions)
+		expr, err := newLinearExpr(m.rows[i], pivotMap[i], pivotExpressions)
+		if err != nil {
+			return 0, err
+		}
+
+		pivotExpressions[pivotMap[i]] = expr
 	}
 
 	smallestSumValues := math.MaxInt
 
-	for _, combination := range enumerateFreeVariableCombinations(m.deriveLimits(pivotExpressions)) {
-		values := evaluateExpressions(pivotExpressions, combination)
+	lims, err := m.deriveLimits(ctx, pivotExpressions)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, combination := range enumerateFreeVariableCombinations(ctx, lims) {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		values, err := evaluateExpressions(pivotExpressions, combination)
+		if err != nil {
+			return 0, err
+		}
+
 		if values == nil {
 			continue
 		}
 
-		sum := support.SumSeq(maps.Values(values))
+		sum := 0
+		for v := range maps.Values(values) {
+			if sum, err = support.AddChecked(sum, v); err != nil {
+				return 0, err
+			}
+		}
 
 		if sum < smallestSumValues {
 			smallestSumValues = sum
 		}
 	}
 
-	return smallestSumValues
+	if smallestSumValues == math.MaxInt {
+		return 0, errors.New("no combination of non-negative presses solves the matrix")
+	}
+
+	return smallestSumValues, nil
 }
 
 type limits struct {
@@ -210,12 +385,15 @@ type limits struct {
 // f <= 3 -> a clear max on f
 //
 // However, for equations with multiple variables we might need to do multiple passes so we can sub in other min/max
-// values we've already determined.
-func (m *AugmentedMatrix) deriveLimits(pivotExpressions map[int]linearExpr) map[int]limits {
+// values we've already determined. Gives up with ctx's error once it's done, as converging can take many passes.
+func (m *AugmentedMatrix) deriveLimits(
+	ctx context.Context,
+	pivotExpressions map[int]linearExpr,
+) (map[int]limits, error) {
 	lims := make(map[int]limits)
 
 	// Initialise all limits to [0, math.MaxInt]
-	for col := range m.rows[0].coefficients {
+	for col := range m.cols {
 		// Not a free variable
 		if _, ok := pivotExpressions[col]; ok {
 			continue
@@ -228,13 +406,20 @@ func (m *AugmentedMatrix) deriveLimits(pivotExpressions map[int]linearExpr) map[
 	// continue looping until we have no longer narrowed any bounds
 	narrowedBounds := true
 	for narrowedBounds {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
 		narrowedBounds = false
 
 		for _, e := range pivotExpressions {
 		boundsLoop:
 			for col, coeff := range e.freeVarCoeffs {
 				// Rearrange each equation in terms of each free variable
-				value := e.constant * -1
+				value, err := support.MulChecked(e.constant, -1)
+				if err != nil {
+					return nil, err
+				}
 
 				freeVarCoeffs := make(map[int]int)
 				for otherCol, otherCoeff := range e.freeVarCoeffs {
@@ -242,34 +427,45 @@ func (m *AugmentedMatrix) deriveLimits(pivotExpressions map[int]linearExpr) map[
 						continue
 					}
 
-					freeVarCoeffs[otherCol] = otherCoeff * -1
+					if freeVarCoeffs[otherCol], err = support.MulChecked(otherCoeff, -1); err != nil {
+						return nil, err
+					}
 				}
 
 				// A negative coefficient turns our >= 0 expression into a <= when we multiply everything by -1 to turn
 				// -x into x; therefore our minimum bound turns into a maximum bound
 				if coeff < 0 {
 					// Invert the equation to get a positive expression of col
-					coeff *= -1
-					value *= -1
+					if coeff, err = support.MulChecked(coeff, -1); err != nil {
+						return nil, err
+					}
+
+					if value, err = support.MulChecked(value, -1); err != nil {
+						return nil, err
+					}
+
 					for k := range freeVarCoeffs {
-						freeVarCoeffs[k] *= -1
+						freeVarCoeffs[k] *= -1 // Safe, as it was negated from a valid int just above
 
 						// If we are deriving a max, we want the max value for any free variables with a positive
 						// coefficient, and the min value for any with a negative
-						if freeVarCoeffs[k] < 0 {
-							value += lims[k].min * freeVarCoeffs[k]
-						} else {
+						bound := lims[k].min
+						if freeVarCoeffs[k] >= 0 {
 							// If we've not been able to derive a maximum value for this variable yet, we can't proceed,
 							// we need to try again later
 							if lims[k].max == math.MaxInt {
 								continue boundsLoop
 							}
 
-							value += lims[k].max * freeVarCoeffs[k]
+							bound = lims[k].max
+						}
+
+						if value, err = mulAdd(value, bound, freeVarCoeffs[k]); err != nil {
+							return nil, err
 						}
 					}
 
-					value = int(math.Ceil(float64(value) / float64(coeff)))
+					value = ceilDiv(value, coeff)
 
 					if value > 0 && value < lims[col].max {
 						bounds := lims[col]
@@ -282,18 +478,21 @@ func (m *AugmentedMatrix) deriveLimits(pivotExpressions map[int]linearExpr) map[
 					// If we are deriving a min, we want the min value for any free variables with a positive
 					// coefficient, and the max value for any with a negative
 					for k := range freeVarCoeffs {
+						bound := lims[k].min
 						if freeVarCoeffs[k] < 0 {
 							if lims[k].max == math.MaxInt {
 								continue boundsLoop
 							}
 
-							value += lims[k].max * freeVarCoeffs[k]
-						} else {
-							value += lims[k].min * freeVarCoeffs[k]
+							bound = lims[k].max
+						}
+
+						if value, err = mulAdd(value, bound, freeVarCoeffs[k]); err != nil {
+							return nil, err
 						}
 					}
 
-					value = int(math.Ceil(float64(value) / float64(coeff)))
+					value = ceilDiv(value, coeff)
 
 					if value > lims[col].min {
 						bounds := lims[col]
@@ -305,13 +504,21 @@ func (m *AugmentedMatrix) deriveLimits(pivotExpressions map[int]linearExpr) map[
 				}
 			}
 		}
+
+		// Once a variable's limits cross, no choice of presses satisfies every equation
+		for col, val := range lims {
+			if val.min > val.max {
+				return nil, fmt.Errorf("no non-negative solution, the variable in column %d needs %d to %d presses",
+					col, val.min, val.max)
+			}
+		}
 	}
 
-	for _, val := range lims {
+	for col, val := range lims {
 		if val.max == math.MaxInt {
-			panic("Could not determine an upper limit!")
+			return nil, fmt.Errorf("could not determine an upper limit for the variable in column %d", col)
 		}
 	}
 
-	return lims
+	return lims, nil
 }
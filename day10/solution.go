@@ -0,0 +1,226 @@
+package day10
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/day10/augmentedmatrix"
+	"advent-of-code-2025/support"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(10, aoc.Solver[[]machine]{
+		Parse: func(input string) ([]machine, error) {
+			return support.ParseLines(support.Lines(input), parseMachine)
+		},
+		PartOne: partOne,
+		PartTwo: func(ctx context.Context, machines []machine) (int, error) {
+			presses, err := partTwo(ctx, machines, augmentedmatrix.NewRefAugmentedMatrix)
+			if err != nil {
+				return 0, err
+			}
+
+			total := 0
+			for _, p := range presses {
+				if total, err = support.AddChecked(total, p); err != nil {
+					return 0, fmt.Errorf("too many presses to count in an int, try big answers: %w", err)
+				}
+			}
+
+			return total, nil
+		},
+		// Eliminating rows can overflow long before the answer does, and the total over every machine can too
+		PartTwoBig: func(ctx context.Context, machines []machine) (*big.Int, error) {
+			presses, err := partTwo(ctx, machines, augmentedmatrix.NewRefAugmentedMatrixBig)
+			if err != nil {
+				return nil, err
+			}
+
+			total := new(big.Int)
+			for _, p := range presses {
+				total.Add(total, big.NewInt(int64(p)))
+			}
+
+			return total, nil
+		},
+		Examples: []aoc.Example[[]machine]{
+			{Input: exampleInput, PartOne: 7, PartTwo: 33},
+		},
+	})
+}
+
+func partOne(ctx context.Context, machines []machine) (int, error) {
+	// Keep generating all combinations of switches starting at 1 and moving upwards; the first valid solution
+	// will therefore be the smallest.
+	totalPresses := 0
+
+	for i, m := range machines {
+		presses, err := findSmallestSequence(ctx, m)
+		if err != nil {
+			return 0, fmt.Errorf("machine on line %d: %w", i+1, err)
+		}
+
+		totalPresses += presses
+	}
+
+	return totalPresses, nil
+}
+
+// Part two forms a system of vector linear equations where variables a...f are the number of times pressing a button:
+//
+// a(3) + b(1,3) + c(2) + d(2,3) + e(0,2) + f(0,1) = {3,5,4,7}
+// -> a(0, 0, 0, 1) + b(0, 1, 0, 1) + c(0, 0, 1, 0) + d(0, 0, 1, 1) + e(1, 0, 1, 0) + f(1, 1, 0, 0) = {3,5,4,7}
+//
+// These vector equations can then be rewritten as four scalar (?) equations:
+//
+// 0a + 0b + 0c + 0d + 1e + 1f = 3
+// 0a + 1b + 0c + 0d + 0e + 1f = 5
+// 0a + 0b + 1c + 1d + 1e + 0f = 4
+// 1a + 1b + 0c + 1d + 0e + 0f = 7
+//
+// Which can then be represented as an augmented matrix:
+//
+// | 0 0 0 0 1 1 || 3 |
+// | 0 1 0 0 0 1 || 5 |
+// | 0 0 1 1 1 0 || 4 |
+// | 1 1 0 1 0 0 || 7 |
+//
+// We can then convert that to row echelon form to get solutions (see augmentedmatrix.AugmentedMatrix.toRowEchelonForm)
+// using newMatrix. Returns the fewest presses for each machine, leaving the caller to add them up.
+func partTwo(
+	ctx context.Context,
+	machines []machine,
+	newMatrix func(coefficients [][]int, totals []int) (augmentedmatrix.AugmentedMatrix, error),
+) ([]int, error) {
+	presses := make([]int, 0, len(machines))
+
+	for i, m := range machines {
+		// Transform the bitset version of the switches into a regular slice of ints 0...1
+		switches := support.Map(m.switches, func(s support.Bitset) []int {
+			result := make([]int, len(m.joltageLevels))
+			for light := range s.All() {
+				result[light] = 1
+			}
+			return result
+		})
+
+		augmentedMatrix, err := newMatrix(switches, m.joltageLevels)
+		if err != nil {
+			return nil, fmt.Errorf("machine on line %d: %w", i+1, err)
+		}
+
+		machinePresses, err := augmentedMatrix.Solve(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("machine on line %d: %w", i+1, err)
+		}
+
+		presses = append(presses, machinePresses)
+	}
+
+	return presses, nil
+}
+
+type machine struct {
+	lights        support.Bitset   // The target light pattern, with bit i set if light i should be on
+	switches      []support.Bitset // Bitsets that can be XORed against the lights to model pressing that switch
+	joltageLevels []int            // Slice of ints representing the target final joltage levels
+}
+
+// The layout of a machine as written in the input, e.g. "[.##.] (3) (1,3) (2) {3,5,4,7}"
+type machineSpec struct {
+	Lights   string
+	Switches [][]int `sep:" ," trim:"()"`
+	Joltage  []int   `sep:","`
+}
+
+var decodeMachineSpec = support.Decoder[machineSpec]("[{Lights}] {Switches} {{{Joltage}}}")
+
+// Toggling switches is an XOR.
+// XOR is commutative, associative, and its own inverse, meaning in the shortest sequence no button is pressed more than
+// once: pressing it again would undo its effect, regardless of any other buttons pressed in between.
+// I did not know this off the top of my head and had to look it up following a reddit hint.
+// Following this, we can represent the lights as a bitset and the switches as bitsets to XOR onto the lights.
+func parseMachine(m string) (machine, error) {
+	spec, err := decodeMachineSpec(m)
+	if err != nil {
+		return machine{}, err
+	}
+
+	if spec.Lights == "" {
+		return machine{}, errors.New("light pattern is empty")
+	}
+
+	lights, err := support.ParseBitset(spec.Lights)
+	if err != nil {
+		return machine{}, fmt.Errorf("light pattern: %w", err)
+	}
+
+	// Convert each switch to a bitset representing the XOR that pressing it would perform
+	switches := make([]support.Bitset, 0, len(spec.Switches))
+
+	for _, toggles := range spec.Switches {
+		if len(toggles) == 0 {
+			return machine{}, errors.New("switch () doesn't toggle any lights")
+		}
+
+		switchBitset := support.NewBitset(lights.Width())
+		for _, toggle := range toggles {
+			if toggle < 0 || toggle >= len(spec.Lights) {
+				return machine{}, fmt.Errorf(
+					"switch %v toggles light %d but there are only %d", toggles, toggle, len(spec.Lights),
+				)
+			}
+
+			switchBitset.Set(toggle)
+		}
+
+		switches = append(switches, switchBitset)
+	}
+
+	if len(spec.Joltage) != len(spec.Lights) {
+		return machine{}, fmt.Errorf(
+			"expected %d joltage levels, one per light, got %d", len(spec.Lights), len(spec.Joltage),
+		)
+	}
+
+	return machine{lights: lights, switches: switches, joltageLevels: spec.Joltage}, nil
+}
+
+// How many combinations of switches to try between looking for cancellation
+const cancellationInterval = 1 << 16
+
+func findSmallestSequence(ctx context.Context, m machine) (int, error) {
+	tried := 0
+
+	// Reused for every combination, as allocating one each time would dominate
+	lights := support.NewBitset(m.lights.Width())
+
+	for presses := 1; presses <= len(m.switches); presses++ {
+		for combination := range support.Combinations(m.switches, presses) {
+			// There can be billions of combinations, so keep an eye on ctx throughout
+			if tried%cancellationInterval == 0 && ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+
+			tried++
+
+			lights.ClearAll()
+
+			for _, press := range combination {
+				lights.XorWith(press)
+			}
+
+			if lights.Equal(m.lights) {
+				return presses, nil
+			}
+		}
+	}
+
+	return 0, errors.New("could not find a valid combination")
+}
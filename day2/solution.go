@@ -0,0 +1,318 @@
+package day2
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(2, aoc.Solver[[][]int]{
+		Parse:   parseRanges,
+		PartOne: partOne,
+		PartTwo: partTwo,
+		Explain: explain,
+		Examples: []aoc.Example[[][]int]{
+			{Input: exampleInput, PartOne: 1227775554, PartTwo: 4174379265},
+		},
+	})
+}
+
+// The input is a single line of comma separated ranges e.g. "11-22,95-115"
+func parseRanges(in string) ([][]int, error) {
+	input := support.Fields(support.Normalise(in), ",")
+	ranges := make([][]int, len(input))
+
+	for idx, rangeString := range input {
+		r, err := support.IntFields(rangeString, "-")
+		if err == nil && len(r) != 2 {
+			err = errors.New("expected a range like 11-22")
+		}
+
+		if err != nil {
+			return nil, support.LineError(1, rangeString, err)
+		}
+
+		ranges[idx] = r
+	}
+
+	return ranges, nil
+}
+
+// A Rule says which IDs are invalid: those whose digits in Radix are a single block repeated between MinRepeats and
+// MaxRepeats times. Setting both to the same count asks for exactly that many repeats.
+//
+// Rules are only for calling SumInvalidIds from Go, e.g. to explore variants of the puzzle; the aoc command always
+// solves with PartOneRule and PartTwoRule.
+type Rule struct {
+	MinRepeats int
+	MaxRepeats int // Zero for no limit
+	Radix      int
+}
+
+var (
+	// A block repeated exactly twice, e.g. 123123
+	PartOneRule = ExactRule(2, 10)
+
+	// A block repeated any number of times, e.g. 123123123
+	PartTwoRule = Rule{MinRepeats: 2, Radix: 10}
+)
+
+// A rule for a block repeated exactly repeats times in the given radix.
+func ExactRule(repeats, radix int) Rule {
+	return Rule{MinRepeats: repeats, MaxRepeats: repeats, Radix: radix}
+}
+
+func (r Rule) Validate() error {
+	switch {
+	case r.Radix < 2 || r.Radix > 36:
+		return fmt.Errorf("radix must be between 2 and 36, got %d", r.Radix)
+	case r.MinRepeats < 2:
+		return fmt.Errorf("a block must repeat at least twice, got a minimum of %d", r.MinRepeats)
+	case r.MaxRepeats != 0 && r.MaxRepeats < r.MinRepeats:
+		return fmt.Errorf("maximum of %d repeats is below the minimum of %d", r.MaxRepeats, r.MinRepeats)
+	}
+
+	return nil
+}
+
+func (r Rule) allows(repeats int) bool {
+	return repeats >= r.MinRepeats && (r.MaxRepeats == 0 || repeats <= r.MaxRepeats)
+}
+
+// Report whether id is invalid under the rule by checking its digits directly. Negative IDs never are.
+func (r Rule) Matches(id int) bool {
+	if id < 0 {
+		return false
+	}
+
+	digits := strconv.FormatInt(int64(id), r.Radix)
+
+	// Given e.g. 12341234 and a minimum of 2, check if 1234 1234 == 1234 1234, then 12 12 12 12 == 12 34 12 34 and so
+	// on, skipping counts that don't divide the length
+	for repeats := r.MinRepeats; repeats <= len(digits) && r.allows(repeats); repeats++ {
+		if len(digits)%repeats != 0 {
+			continue
+		}
+
+		if strings.Repeat(digits[:len(digits)/repeats], repeats) == digits {
+			return true
+		}
+	}
+
+	return false
+}
+
+func partOne(_ context.Context, ranges [][]int) (int, error) {
+	return SumInvalidIds(ranges, PartOneRule)
+}
+
+func partTwo(_ context.Context, ranges [][]int) (int, error) {
+	return SumInvalidIds(ranges, PartTwoRule)
+}
+
+// Sum the IDs across every range that are invalid under rule.
+//
+// Repeating a block b of p digits n times gives b * m, where m is 1 followed by p-1 zeros, repeated n times (1001 for
+// 123123), so rather than checking every ID in a range we work out which blocks land inside it and sum them in one go.
+func SumInvalidIds(ranges [][]int, rule Rule) (int, error) {
+	if err := rule.Validate(); err != nil {
+		return 0, err
+	}
+
+	total := 0
+
+	for _, r := range ranges {
+		// Single digit IDs can't repeat
+		for length := 2; length <= len(strconv.FormatInt(int64(r[1]), rule.Radix)); length++ {
+			sum, err := rule.sumOfLength(max(r[0], 0), r[1], length)
+			if err == nil {
+				total, err = support.AddChecked(total, sum)
+			}
+
+			if err != nil {
+				return 0, fmt.Errorf("summing invalid IDs in %d-%d: %w", r[0], r[1], err)
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// Sum the IDs of length digits in lo..hi that are invalid under the rule.
+func (r Rule) sumOfLength(lo, hi, length int) (int, error) {
+	// Group IDs by their shortest repeating block. One whose shortest block has p digits is also made of blocks of
+	// every multiple of p dividing length, e.g. 111111 is 1 x 6, 11 x 3 and 111 x 2, so it's invalid if any of those
+	// repeat counts (the divisors of length/p) is allowed. Summing every ID that repeats with period p would count
+	// the ones with shorter blocks too, so we subtract those, working up from the shortest, so each ID is counted
+	// exactly once.
+	periods := divisors(length)
+	shortest := make(map[int]int, len(periods)) // Sum of IDs by the length of their shortest block
+	total := 0
+
+	// A block of every digit isn't repeated, so the last divisor, length itself, is left out
+	for _, period := range periods[:len(periods)-1] {
+		sum, err := sumRepeated(lo, hi, length, period, r.Radix)
+		if err != nil {
+			return 0, err
+		}
+
+		shorterPeriods := divisors(period)
+		for _, shorter := range shorterPeriods[:len(shorterPeriods)-1] {
+			if sum, err = support.SubChecked(sum, shortest[shorter]); err != nil {
+				return 0, err
+			}
+		}
+
+		shortest[period] = sum
+
+		if slices.ContainsFunc(divisors(length/period), r.allows) {
+			if total, err = support.AddChecked(total, sum); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// Sum the IDs in lo..hi made of a block of period digits in radix repeated to make length digits.
+func sumRepeated(lo, hi, length, period, radix int) (int, error) {
+	blockLimit, err := support.Pow(radix, period)
+	if err != nil {
+		return 0, err
+	}
+
+	multiplier := 0
+	for range length / period {
+		if multiplier, err = support.MulChecked(multiplier, blockLimit); err != nil {
+			return 0, err
+		}
+
+		multiplier++
+	}
+
+	// Blocks can't start with a 0, which also makes sure every ID they give has exactly length digits
+	first := max(blockLimit/radix, lo/multiplier)
+	if first*multiplier < lo {
+		first++
+	}
+
+	last := min(blockLimit-1, hi/multiplier)
+	if first > last {
+		return 0, nil
+	}
+
+	// The blocks run first..last, so sum them as (first + last) * count / 2, halving whichever is even before
+	// multiplying so the sum only overflows if the answer would
+	pairSum, count := first+last, last-first+1
+	if pairSum%2 == 0 {
+		pairSum /= 2
+	} else {
+		count /= 2
+	}
+
+	blockSum, err := support.MulChecked(pairSum, count)
+	if err != nil {
+		return 0, err
+	}
+
+	return support.MulChecked(blockSum, multiplier)
+}
+
+// The divisors of n, smallest first.
+func divisors(n int) []int {
+	found := make([]int, 0)
+
+	for d := 1; d <= n; d++ {
+		if n%d == 0 {
+			found = append(found, d)
+		}
+	}
+
+	return found
+}
+
+// Ranges with more IDs than this are too slow to check with SumInvalidIdsBruteForce in explain
+const bruteForceLimit = 1_000_000
+
+// List each range's invalid ID sums, checked against the brute force version where the range is small enough.
+func explain(ctx context.Context, ranges [][]int, w io.Writer) error {
+	rules := [2]Rule{PartOneRule, PartTwoRule}
+
+	for _, r := range ranges {
+		single := [][]int{r}
+		sums := [2]int{}
+
+		for i, rule := range rules {
+			sum, err := SumInvalidIds(single, rule)
+			if err != nil {
+				return err
+			}
+
+			sums[i] = sum
+		}
+
+		check := "too big to brute force"
+
+		if r[1]-r[0] < bruteForceLimit {
+			check = "brute force agrees"
+
+			for i, rule := range rules {
+				sum, err := SumInvalidIdsBruteForce(ctx, single, rule)
+				if err != nil {
+					return err
+				}
+
+				if sum != sums[i] {
+					check = fmt.Sprintf("but brute force gives %d for part %d", sum, i+1)
+					break
+				}
+			}
+		}
+
+		_, err := fmt.Fprintf(w, "%d-%d: part one %d, part two %d, %s\n", r[0], r[1], sums[0], sums[1], check)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// How many IDs to check between looking for cancellation
+const cancellationInterval = 1 << 16
+
+// As SumInvalidIds, but done the obvious way by checking every ID in turn. Far too slow for big ranges, but kept as a
+// reference for the arithmetic version.
+func SumInvalidIdsBruteForce(ctx context.Context, ranges [][]int, rule Rule) (int, error) {
+	if err := rule.Validate(); err != nil {
+		return 0, err
+	}
+
+	total := 0
+
+	for _, r := range ranges {
+		for i := r[0]; i <= r[1]; i++ {
+			if i%cancellationInterval == 0 && ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+
+			if rule.Matches(i) {
+				total += i
+			}
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,146 @@
+package day2
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+)
+
+// Check the arithmetic sum against checking every ID, for every range on its own and for all of them together.
+func checkAgainstBruteForce(t *testing.T, ranges [][]int, rule Rule) {
+	t.Helper()
+
+	for _, r := range append(ranges, nil) {
+		subset := [][]int{r}
+		if r == nil {
+			subset = ranges
+		}
+
+		want, err := SumInvalidIdsBruteForce(context.Background(), subset, rule)
+		if err != nil {
+			t.Fatalf("brute force with %+v: %v", rule, err)
+		}
+
+		got, err := SumInvalidIds(subset, rule)
+		if err != nil {
+			t.Fatalf("SumInvalidIds(%v, %+v): %v", subset, rule, err)
+		}
+
+		if got != want {
+			t.Errorf("SumInvalidIds(%v, %+v) = %d, brute force gives %d", subset, rule, got, want)
+		}
+	}
+}
+
+func TestSumInvalidIdsMatchesBruteForceOnExample(t *testing.T) {
+	ranges, err := parseRanges(exampleInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rule := range []Rule{PartOneRule, PartTwoRule} {
+		checkAgainstBruteForce(t, ranges, rule)
+	}
+}
+
+func TestSumInvalidIdsMatchesBruteForceOnRandomRanges(t *testing.T) {
+	rng := rand.New(rand.NewPCG(2, 2025))
+	ranges := make([][]int, 0)
+
+	// Mostly short ranges at every scale, so they cross digit lengths and catch both ends of a block's run
+	for range 500 {
+		lo := rng.IntN(1_000_000 >> rng.IntN(20))
+		ranges = append(ranges, []int{lo, lo + rng.IntN(2_000)})
+	}
+
+	for _, rule := range []Rule{PartOneRule, PartTwoRule} {
+		checkAgainstBruteForce(t, ranges, rule)
+	}
+}
+
+func TestNonDefaultRulesMatchBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(25, 2025))
+	ranges := make([][]int, 0)
+
+	for range 200 {
+		lo := rng.IntN(1_000_000 >> rng.IntN(20))
+		ranges = append(ranges, []int{lo, lo + rng.IntN(2_000)})
+	}
+
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"binary, any repeats", Rule{MinRepeats: 2, Radix: 2}},
+		{"binary, exactly twice", ExactRule(2, 2)},
+		{"hex, any repeats", Rule{MinRepeats: 2, Radix: 16}},
+		{"hex, exactly twice", ExactRule(2, 16)},
+		{"exactly three times", ExactRule(3, 10)},
+		{"two to three times", Rule{MinRepeats: 2, MaxRepeats: 3, Radix: 10}},
+		{"three to four times in base 36", Rule{MinRepeats: 3, MaxRepeats: 4, Radix: 36}},
+		{"at least four times", Rule{MinRepeats: 4, Radix: 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkAgainstBruteForce(t, ranges, test.rule)
+		})
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		id   int
+		want bool
+	}{
+		{ExactRule(2, 2), 0b1010, true},
+		{ExactRule(2, 2), 0b101010, false},
+		{Rule{MinRepeats: 2, Radix: 2}, 0b101010, true},
+		{ExactRule(2, 16), 0xabab, true},
+		{ExactRule(2, 16), 0xabac, false},
+		{ExactRule(3, 10), 123123123, true},
+		{ExactRule(3, 10), 123123, false},
+		{Rule{MinRepeats: 2, MaxRepeats: 3, Radix: 10}, 1111, true}, // 11 twice
+		{Rule{MinRepeats: 2, MaxRepeats: 3, Radix: 10}, 12121212, true},
+		{Rule{MinRepeats: 2, MaxRepeats: 3, Radix: 10}, 1212121212, false},
+		{Rule{MinRepeats: 2, MaxRepeats: 3, Radix: 10}, 11111, false},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.Matches(test.id); got != test.want {
+			t.Errorf("%+v Matches(%d) = %v, want %v", test.rule, test.id, got, test.want)
+		}
+	}
+}
+
+func TestValidateRejectsBadRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"radix below 2", Rule{MinRepeats: 2, Radix: 1}},
+		{"radix above 36", Rule{MinRepeats: 2, Radix: 37}},
+		{"zero value", Rule{}},
+		{"fewer than two repeats", Rule{MinRepeats: 1, Radix: 10}},
+		{"maximum below minimum", Rule{MinRepeats: 3, MaxRepeats: 2, Radix: 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.rule.Validate(); err == nil {
+				t.Errorf("Validate() accepted %+v", test.rule)
+			}
+
+			if _, err := SumInvalidIds([][]int{{1, 100}}, test.rule); err == nil {
+				t.Errorf("SumInvalidIds accepted %+v", test.rule)
+			}
+		})
+	}
+
+	for _, rule := range []Rule{PartOneRule, PartTwoRule, ExactRule(2, 2), ExactRule(5, 36)} {
+		if err := rule.Validate(); err != nil {
+			t.Errorf("Validate() rejected %+v: %v", rule, err)
+		}
+	}
+}
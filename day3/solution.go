@@ -0,0 +1,70 @@
+package day3
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+)
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(3, aoc.Solver[[][]int]{
+		Parse: func(input string) ([][]int, error) {
+			return support.ParseLines(support.Lines(input), support.StringOfDigitsAsSliceOfInts)
+		},
+		PartOne: func(_ context.Context, input [][]int) (int, error) { return solvePart(input, 2) },
+		PartTwo: func(_ context.Context, input [][]int) (int, error) { return solvePart(input, 12) },
+		Examples: []aoc.Example[[][]int]{
+			{Input: exampleInput, PartOne: 357, PartTwo: 3121910778619},
+		},
+	})
+}
+
+func solvePart(input [][]int, batteryCount int) (int, error) {
+	results := 0
+
+	for i, line := range input {
+		if len(line) < batteryCount {
+			return 0, fmt.Errorf("bank on line %d has %d batteries but %d are needed", i+1, len(line), batteryCount)
+		}
+
+		result := solveLine(line, batteryCount)
+
+		resultAsInt, err := strconv.Atoi(result)
+		if err != nil {
+			return 0, fmt.Errorf("could not convert %s from line %d to int: %w", result, i+1, err)
+		}
+
+		results += resultAsInt
+	}
+
+	return results, nil
+}
+
+func solveLine(line []int, targetLength int) string {
+	if targetLength == 0 {
+		return ""
+	}
+
+	// Given we can't rearrange batteries, we have to choose the largest digit that has enough digits to the right to
+	// make up to target length.
+	leftIndex, leftDigit := findMax(line[:(len(line)-targetLength)+1])
+
+	// Then just do this recursively with those digits to the right.
+	return strconv.Itoa(leftDigit) + solveLine(line[leftIndex+1:], targetLength-1)
+}
+
+// Returns (indexOfLargestValue, largestValue) from line, taking the leftmost on a tie; (-1, -1) for empty slices.
+func findMax(line []int) (int, int) {
+	index, ok := support.MaxBySeq(support.RangeSeq(0, len(line), 1), func(i int) int { return line[i] })
+	if !ok {
+		return -1, -1
+	}
+
+	return index, line[index]
+}
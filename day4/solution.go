@@ -0,0 +1,103 @@
+package day4
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"context"
+	_ "embed"
+	"fmt"
+)
+
+const roll rune = '@'
+const emptySpace rune = '.'
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(4, aoc.Solver[*support.Grid[rune]]{
+		Parse:   parseGrid,
+		PartOne: partOne,
+		PartTwo: partTwo,
+		Examples: []aoc.Example[*support.Grid[rune]]{
+			{Input: exampleInput, PartOne: 13, PartTwo: 43},
+		},
+	})
+}
+
+// Parse the grid, making sure it's rectangular and only contains rolls and empty space
+func parseGrid(input string) (*support.Grid[rune], error) {
+	grid, err := support.ParseGrid(input)
+	if err != nil {
+		return nil, err
+	}
+
+	for p, cell := range grid.All() {
+		if cell != roll && cell != emptySpace {
+			return nil, &support.ParseError{
+				Line:   p.Y + 1,
+				Column: p.X + 1,
+				Text:   string(grid.Row(p.Y)),
+				Err:    fmt.Errorf("unexpected %q", cell),
+			}
+		}
+	}
+
+	return grid, nil
+}
+
+func partOne(_ context.Context, grid *support.Grid[rune]) (int, error) {
+	return len(getReachableRolls(grid)), nil
+}
+
+func partTwo(ctx context.Context, grid *support.Grid[rune]) (int, error) {
+	totalRemovableRolls := 0
+
+	// We remove rolls as we go, so work on a copy to leave the parsed input intact
+	grid = grid.Clone()
+
+	for ctx.Err() == nil {
+		removableRolls := getReachableRolls(grid)
+
+		if len(removableRolls) == 0 {
+			break
+		}
+
+		totalRemovableRolls += len(removableRolls)
+		removeRolls(grid, removableRolls)
+	}
+
+	return totalRemovableRolls, ctx.Err()
+}
+
+// Return the coordinates of all removable rolls
+func getReachableRolls(grid *support.Grid[rune]) []support.Point2 {
+	reachableRolls := make([]support.Point2, 0)
+
+	for p, cell := range grid.All() {
+		if cell != roll {
+			continue
+		}
+
+		neighbouringRolls := 0
+
+		for _, neighbour := range grid.Neighbours8(p) {
+			if neighbour == roll {
+				neighbouringRolls++
+			}
+		}
+
+		if neighbouringRolls < 4 {
+			reachableRolls = append(reachableRolls, p)
+		}
+	}
+
+	return reachableRolls
+}
+
+// Remove the rolls specified by rollCoords from grid
+func removeRolls(grid *support.Grid[rune], rollCoords []support.Point2) {
+	for _, coord := range rollCoords {
+		grid.Set(coord, emptySpace)
+	}
+}
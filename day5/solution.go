@@ -0,0 +1,228 @@
+package day5
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"bufio"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(5, aoc.Solver[inventory]{
+		Parse:   parseInventory,
+		PartOne: func(_ context.Context, inv inventory) (int, error) { return partOne(inv), nil },
+		PartTwo: func(_ context.Context, inv inventory) (int, error) { return partTwo(inv), nil },
+		Explain: explain,
+		Stream:  stream,
+		Examples: []aoc.Example[inventory]{
+			{Input: exampleInput, PartOne: 3, PartTwo: 14},
+		},
+	})
+}
+
+type inventory struct {
+	ranges        *support.IntervalTree[int] // The fresh ID ranges as given, labelled with their line number
+	freshIds      *support.IntervalSet       // The same ranges merged
+	ingredientIds []int
+}
+
+func parseInventory(input string) (inventory, error) {
+	rangesAndIngredientIds := support.Sections(input)
+	if len(rangesAndIngredientIds) != 2 {
+		return inventory{}, errors.New("expected ranges and ingredient IDs separated by a blank line")
+	}
+
+	rangeSection := rangesAndIngredientIds[0]
+
+	ranges, err := support.ParseSection(rangeSection, rangeFromString)
+	if err != nil {
+		return inventory{}, err
+	}
+
+	labelledRanges := make([]support.LabelledInterval[int], 0, len(ranges))
+	for i, r := range ranges {
+		lineNo := rangeSection.Start + i
+		labelledRanges = append(labelledRanges, support.LabelledInterval[int]{Interval: r, Label: lineNo})
+	}
+
+	tree := support.NewIntervalTree(labelledRanges...)
+
+	ingredientIds, err := support.ParseSection(rangesAndIngredientIds[1], strconv.Atoi)
+	if err != nil {
+		return inventory{}, err
+	}
+
+	// Merging overlapping ranges makes part two super easy
+	return inventory{ranges: tree, freshIds: tree.Merged(), ingredientIds: ingredientIds}, nil
+}
+
+func partOne(inv inventory) int {
+	freshCount := 0
+
+	for _, id := range inv.ingredientIds {
+		if inv.freshIds.Contains(id) {
+			freshCount++
+		}
+	}
+
+	return freshCount
+}
+
+func partTwo(inv inventory) int {
+	return inv.freshIds.Size()
+}
+
+// List the ranges each ingredient ID falls in, to show why it's counted as fresh or not.
+func explain(_ context.Context, inv inventory, w io.Writer) error {
+	for _, id := range inv.ingredientIds {
+		covering := slices.Collect(inv.ranges.Covering(id))
+
+		if len(covering) == 0 {
+			if _, err := fmt.Fprintf(w, "%d: spoiled, in no range\n", id); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		described := support.Map(covering, func(r support.LabelledInterval[int]) string {
+			return fmt.Sprintf("%v (line %d)", r.Interval, r.Label)
+		})
+
+		if _, err := fmt.Fprintf(w, "%d: fresh, in %s\n", id, strings.Join(described, ", ")); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(
+		w,
+		"%d ranges merge into %d covering %d IDs: %v\n",
+		inv.ranges.Len(), inv.freshIds.Len(), inv.freshIds.Size(), inv.freshIds,
+	)
+
+	return err
+}
+
+// Classify ingredient IDs as they're read from r, so only the ranges are held in memory. Each ID's verdict is written
+// with the running counts, then the totals, where the fresh count is the part one answer.
+func stream(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	// The ranges come first, up to the blank line
+	ranges := make([]support.Interval, 0)
+	separated := false
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if line == "" {
+			separated = len(ranges) > 0
+			if separated {
+				break
+			}
+
+			continue
+		}
+
+		rng, err := rangeFromString(line)
+		if err != nil {
+			return support.LineError(lineNo, line, err)
+		}
+
+		ranges = append(ranges, rng)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if !separated {
+		return errors.New("expected ranges and ingredient IDs separated by a blank line")
+	}
+
+	freshIds := support.NewIntervalSet(ranges...)
+	fresh, spoiled := 0, 0
+	blankLineNo := 0 // Where the IDs stopped, if they have
+
+	for scanner.Scan() {
+		lineNo++
+
+		// Checking on every line would slow down the hundreds of millions of lines this is for
+		if lineNo%4096 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if line == "" {
+			// Blank lines before the first ID or at the very end are fine, but not between IDs
+			if fresh+spoiled > 0 && blankLineNo == 0 {
+				blankLineNo = lineNo
+			}
+
+			continue
+		}
+
+		if blankLineNo != 0 {
+			return support.LineError(
+				lineNo, line, fmt.Errorf("ingredient IDs carry on after the blank line on line %d", blankLineNo),
+			)
+		}
+
+		id, err := strconv.Atoi(line)
+		if err != nil {
+			return support.LineError(lineNo, line, err)
+		}
+
+		verdict := "spoiled"
+		if freshIds.Contains(id) {
+			verdict = "fresh"
+			fresh++
+		} else {
+			spoiled++
+		}
+
+		if _, err := fmt.Fprintf(w, "%d: %s (%d fresh, %d spoiled so far)\n", id, verdict, fresh, spoiled); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d of %d ingredients are fresh\n", fresh, fresh+spoiled)
+
+	return err
+}
+
+// Given a string e.g. "3-5" return the interval 3-5
+func rangeFromString(str string) (support.Interval, error) {
+	upperAndLowerBound, err := support.IntFields(str, "-")
+	if err != nil {
+		return support.Interval{}, err
+	}
+
+	if len(upperAndLowerBound) != 2 {
+		return support.Interval{}, fmt.Errorf("could not get two parts from %s", str)
+	}
+
+	interval := support.Interval{Start: upperAndLowerBound[0], End: upperAndLowerBound[1]}
+	if interval.IsEmpty() {
+		return support.Interval{}, fmt.Errorf("range %s ends before it starts", str)
+	}
+
+	return interval, nil
+}
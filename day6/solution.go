@@ -0,0 +1,212 @@
+package day6
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(6, aoc.Solver[worksheet]{
+		Parse:   parseWorksheet,
+		PartOne: func(_ context.Context, w worksheet) (int, error) { return w.rows.sum() },
+		PartTwo: func(_ context.Context, w worksheet) (int, error) { return w.columns.sum() },
+		Examples: []aoc.Example[worksheet]{
+			{Input: exampleInput, PartOne: 4277556, PartTwo: 3263827},
+		},
+	})
+}
+
+// The problems on the worksheet as each part reads them. A worksheet can make sense one way and not the other, e.g.
+// with ragged lines that only matter column by column, so each reading keeps its own error for its part to report.
+type worksheet struct {
+	rows    problemsOrError // Part one: the numbers run along the rows
+	columns problemsOrError // Part two: the numbers run down the columns
+}
+
+type problemsOrError struct {
+	problems []Problem
+	err      error
+}
+
+func (p problemsOrError) sum() (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+
+	return support.SumSeq(support.MapSeq(slices.Values(p.problems), Problem.evaluate)), nil
+}
+
+func parseWorksheet(input string) (worksheet, error) {
+	lines, err := worksheetLines(input)
+	if err != nil {
+		return worksheet{}, err
+	}
+
+	var w worksheet
+	w.rows.problems, w.rows.err = readRows(lines)
+	w.columns.problems, w.columns.err = readColumns(lines)
+
+	return w, nil
+}
+
+// Split the worksheet into lines, keeping the spacing as it's significant in part two.
+func worksheetLines(input string) ([]string, error) {
+	lines := support.RawLines(input)
+	if len(lines) == 0 {
+		return nil, errors.New("worksheet is empty")
+	}
+
+	return lines, nil
+}
+
+// Read the problems as columns of whole numbers, one to a line, with the operators on the last line.
+func readRows(lines []string) ([]Problem, error) {
+	components := make([][]string, len(lines))
+
+	whitespace := regexp.MustCompile(`\s+`)
+
+	// Break the numbers and operators into cells...
+	for i, line := range lines {
+		components[i] = whitespace.Split(strings.TrimSpace(line), -1)
+
+		if len(components[i]) != len(components[0]) {
+			return nil, &support.ParseError{
+				Line: i + 1,
+				Text: line,
+				Err: fmt.Errorf(
+					"expected %d cells like the first line, got %d", len(components[0]), len(components[i]),
+				),
+			}
+		}
+	}
+
+	// Then transpose the cells such that we have a list of operands terminated by an operation
+	problems := make([]Problem, 0, len(components[0]))
+
+	for i, problem := range support.Transpose(components) {
+		operator, err := stringOpToFuncOp(problem[len(problem)-1])
+		if err != nil {
+			return nil, &support.ParseError{Line: len(lines), Text: lines[len(lines)-1], Err: err}
+		}
+
+		operands, err := support.SliceOfNumericStringsToSliceOfInts(problem[:len(problem)-1])
+		if err != nil {
+			return nil, fmt.Errorf("problem %d: %w", i+1, err)
+		}
+
+		problems = append(problems, Problem{operator: operator, operands: operands})
+	}
+
+	return problems, nil
+}
+
+// Read the problems right to left with each number's digits running down a column, and the operators on the last line.
+func readColumns(lines []string) ([]Problem, error) {
+	// We read the worksheet column by column, so every line needs to be padded out to the same width
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, &support.ParseError{
+				Line: i + 1,
+				Text: line,
+				Err:  fmt.Errorf("expected %d characters like the first line, got %d", len(lines[0]), len(line)),
+			}
+		}
+	}
+
+	problems := make([]Problem, 0)
+	operands := make([]string, 0)
+
+	// Process the input right-to-left, column-wise
+	for i := len(lines[0]) - 1; i >= 0; i-- {
+		number := ""
+
+		for j := range lines {
+			digit := lines[j][i]
+
+			// If the final row contains an operator, we're at the end of the problem
+			if j == len(lines)-1 {
+				// We're not at the end of the problem yet
+				if digit == ' ' {
+					continue
+				}
+
+				operator, err := stringOpToFuncOp(string(digit))
+				if err != nil {
+					return nil, &support.ParseError{Line: j + 1, Text: lines[j], Err: err}
+				}
+
+				parsedOperands, err := support.SliceOfNumericStringsToSliceOfInts(operands)
+				if err != nil {
+					return nil, fmt.Errorf("problem ending at column %d: %w", i+1, err)
+				}
+
+				problems = append(problems, Problem{operator: operator, operands: parsedOperands})
+
+				// Reset operands as we're about to start a new problem
+				operands = make([]string, 0)
+
+				// Skip the empty column separating problems
+				i--
+
+				break
+			}
+
+			// If we're not on the last row, add the next digit if we have one...
+			if digit != ' ' {
+				number += string(digit)
+			}
+
+			// ...then if we're at the end of a number, add it to the list of operands
+			if j == len(lines)-2 {
+				operands = append(operands, number)
+				continue
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+type Operator func(...int) int
+
+type Problem struct {
+	operator Operator
+	operands []int
+}
+
+func (p Problem) evaluate() int {
+	return p.operator(p.operands...)
+}
+
+func mult(in ...int) int {
+	return support.Reduce(in, 1, func(acc, v int) int { return acc * v })
+}
+
+func plus(in ...int) int {
+	return support.Reduce(in, 0, func(acc, v int) int { return acc + v })
+}
+
+func stringOpToFuncOp(op string) (Operator, error) {
+	var fnOp Operator
+
+	switch op {
+	case "*":
+		fnOp = mult
+	case "+":
+		fnOp = plus
+	default:
+		return nil, fmt.Errorf("unimplemented operator %q", op)
+	}
+
+	return fnOp, nil
+}
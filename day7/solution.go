@@ -0,0 +1,172 @@
+package day7
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const start rune = 'S'
+const splitter rune = '^'
+const space rune = '.'
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(7, aoc.Solver[manifold]{
+		Parse: parseManifold,
+		PartOne: func(_ context.Context, m manifold) (int, error) {
+			return countBeamSplits(m.grid, m.start, support.NewSet[support.Point2]()), nil
+		},
+		PartTwo: func(_ context.Context, m manifold) (int, error) {
+			paths, err := countBeamPaths(m.grid, m.start, make(map[support.Point2]int), 1, support.AddChecked[int])
+			if err != nil {
+				return 0, fmt.Errorf("too many paths to count in an int, try big answers: %w", err)
+			}
+
+			return paths, nil
+		},
+		PartTwoBig: func(_ context.Context, m manifold) (*big.Int, error) {
+			return countBeamPaths(m.grid, m.start, make(map[support.Point2]*big.Int), big.NewInt(1), addBig)
+		},
+		Examples: []aoc.Example[manifold]{
+			{Input: exampleInput, PartOne: 21, PartTwo: 40},
+		},
+	})
+}
+
+type manifold struct {
+	grid  *support.Grid[rune]
+	start support.Point2
+}
+
+// Parse the grid, making sure the beam can't wander off it or run into anything unexpected on the way down
+func parseManifold(input string) (manifold, error) {
+	grid, err := support.ParseGrid(input)
+	if err != nil {
+		return manifold{}, err
+	}
+
+	for p, cell := range grid.All() {
+		cellError := func(format string, args ...any) error {
+			return &support.ParseError{
+				Line:   p.Y + 1,
+				Column: p.X + 1,
+				Text:   string(grid.Row(p.Y)),
+				Err:    fmt.Errorf(format, args...),
+			}
+		}
+
+		switch cell {
+		case space:
+		case start:
+			if p.Y != 0 {
+				return manifold{}, cellError("start must be on the first line")
+			}
+		case splitter:
+			// Splitting here would send one of the beams off the side of the grid
+			if p.X == 0 || p.X == grid.Width()-1 {
+				return manifold{}, cellError("splitter is on the edge of the grid")
+			}
+		default:
+			return manifold{}, cellError("unexpected %q", cell)
+		}
+	}
+
+	startPos, ok := grid.Find(func(cell rune) bool { return cell == start })
+	if !ok {
+		return manifold{}, errors.New("could not find start in first line of input")
+	}
+
+	return manifold{grid: grid, start: startPos}, nil
+}
+
+// Count the number of unique beam splits as it progresses downwards. Beams can merge again, so we want to make sure we
+// only count each splitter once: encounteredSplitters tracks those we've already seen.
+func countBeamSplits(
+	input *support.Grid[rune],
+	pos support.Point2,
+	encounteredSplitters support.Set[support.Point2],
+) int {
+	if pos.Y == input.Height()-1 {
+		return 0
+	}
+
+	// parseManifold keeps splitters off the edges, so the beam never leaves the grid
+	cell, _ := input.Get(pos)
+
+	switch cell {
+	case splitter:
+		if encounteredSplitters.Has(pos) {
+			return 0
+		}
+
+		encounteredSplitters.Add(pos)
+
+		return 1 +
+			countBeamSplits(input, pos.Step(support.SouthWest), encounteredSplitters) +
+			countBeamSplits(input, pos.Step(support.SouthEast), encounteredSplitters)
+
+	default:
+		// Space or the start; parseManifold has already rejected anything else
+		return countBeamSplits(input, pos.Step(support.South), encounteredSplitters)
+	}
+}
+
+// Count the number of unique paths the beam could take across all splitters. Memoise in cache so it's actually
+// computable.
+//
+// The count doubles with every level of splitters, so it's generic over the type it counts in: one is a single path and
+// add combines the counts either side of a splitter, erroring if they get too big.
+func countBeamPaths[N any](
+	input *support.Grid[rune],
+	pos support.Point2,
+	cache map[support.Point2]N,
+	one N,
+	add func(a, b N) (N, error),
+) (N, error) {
+	if pos.Y == input.Height()-1 {
+		return one, nil
+	}
+
+	cell, _ := input.Get(pos)
+
+	switch cell {
+	case splitter:
+		if val, ok := cache[pos]; ok {
+			return val, nil
+		}
+
+		left, err := countBeamPaths(input, pos.Step(support.SouthWest), cache, one, add)
+		if err != nil {
+			return left, err
+		}
+
+		right, err := countBeamPaths(input, pos.Step(support.SouthEast), cache, one, add)
+		if err != nil {
+			return right, err
+		}
+
+		paths, err := add(left, right)
+		if err != nil {
+			return paths, err
+		}
+
+		cache[pos] = paths
+
+		return paths, nil
+
+	default:
+		// Space or the start; parseManifold has already rejected anything else
+		return countBeamPaths(input, pos.Step(support.South), cache, one, add)
+	}
+}
+
+func addBig(a, b *big.Int) (*big.Int, error) {
+	return new(big.Int).Add(a, b), nil
+}
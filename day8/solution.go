@@ -0,0 +1,200 @@
+package day8
+
+import (
+	"advent-of-code-2025/aoc"
+	"advent-of-code-2025/support"
+	"cmp"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+//go:embed testdata/example.txt
+var exampleInput string
+
+func init() {
+	aoc.Register(8, aoc.Solver[playground]{
+		Parse: func(input string) (playground, error) {
+			boxes, err := parsePositions(input)
+			if err != nil {
+				return playground{}, err
+			}
+
+			return playground{boxes: boxes, connections: partOneConnections}, nil
+		},
+		// Each part sorts the pairs by distance itself, as that is most of the work and belongs in its timings
+		PartOne: func(_ context.Context, p playground) (int, error) {
+			distances, err := getOrderedBoxPairDistances(p.boxes)
+			if err != nil {
+				return 0, err
+			}
+
+			if p.connections > len(distances) {
+				return 0, fmt.Errorf(
+					"need %d connections but there are only %d pairs of boxes", p.connections, len(distances),
+				)
+			}
+
+			return partOne(NewCircuitSet(p.boxes), distances[:p.connections])
+		},
+		PartTwo: func(ctx context.Context, p playground) (int, error) {
+			distances, err := getOrderedBoxPairDistances(p.boxes)
+			if err != nil {
+				return 0, err
+			}
+
+			return partTwo(ctx, NewCircuitSet(p.boxes), distances)
+		},
+		Examples: []aoc.Example[playground]{
+			{
+				Input:   exampleInput,
+				PartOne: 40,
+				PartTwo: 25272,
+				// The example only makes the ten shortest connections
+				Configure: func(p playground) playground {
+					p.connections = 10
+					return p
+				},
+			},
+		},
+	})
+}
+
+type playground struct {
+	boxes       []support.Point3
+	connections int // How many of the shortest connections to make in part one
+}
+
+const partOneConnections = 1000
+
+func partOne(circuitSet *CircuitSet, distances []BoxPairDistance) (int, error) {
+	for _, distance := range distances {
+		if err := circuitSet.ConnectCircuits(distance.left, distance.right); err != nil {
+			return 0, err
+		}
+	}
+
+	largest, err := circuitSet.LargestCircuits(3)
+	if err != nil {
+		return 0, err
+	}
+
+	return support.Reduce(largest, 1, func(product, size int) int { return product * size }), nil
+}
+
+func partTwo(ctx context.Context, circuitSet *CircuitSet, distances []BoxPairDistance) (int, error) {
+	for _, distance := range distances {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		if err := circuitSet.ConnectCircuits(distance.left, distance.right); err != nil {
+			return 0, err
+		}
+
+		if circuitSet.IsThereOnlyOneCircuitYet() {
+			return distance.left.X * distance.right.X, nil
+		}
+	}
+
+	return 0, errors.New("there's still more than one circuit after making every connection")
+}
+
+type BoxPairDistance struct {
+	left     support.Point3
+	right    support.Point3
+	distance int64 // Squared, as it's exact and sorts the same as the real distance
+}
+
+// Given a slice of points in 3D space, return a slice of each pair and their distance, ordered by distance ascending.
+// Each pair of points only appears in one order, left before right as they appear in the input. Pairs the same distance
+// apart stay in input order too, so the connections made are the same every run.
+func getOrderedBoxPairDistances(boxes []support.Point3) ([]BoxPairDistance, error) {
+	uniqueBoxes := slices.Collect(support.NewOrderedSet(boxes...).All())
+	orderedDistances := make([]BoxPairDistance, 0, len(uniqueBoxes)*(len(uniqueBoxes)-1)/2)
+
+	for i, left := range uniqueBoxes {
+		for _, right := range uniqueBoxes[i+1:] {
+			distance, err := left.SquaredDistanceTo(right)
+			if err != nil {
+				return nil, err
+			}
+
+			orderedDistances = append(orderedDistances, BoxPairDistance{left: left, right: right, distance: distance})
+		}
+	}
+
+	slices.SortStableFunc(orderedDistances, func(a BoxPairDistance, b BoxPairDistance) int {
+		return cmp.Compare(a.distance, b.distance)
+	})
+
+	return orderedDistances, nil
+}
+
+// Tracks which circuit each junction box is on. Boxes are kept in the order they were given so that walking the
+// circuits visits them the same way every run.
+type CircuitSet struct {
+	circuitMap *support.OrderedMap[support.Point3, int]
+}
+
+func NewCircuitSet(junctionBoxes []support.Point3) *CircuitSet {
+	set := CircuitSet{
+		circuitMap: support.NewOrderedMap[support.Point3, int](),
+	}
+
+	for i, box := range junctionBoxes {
+		// Start with every junction box being on its own circuit
+		set.circuitMap.Set(box, i)
+	}
+
+	return &set
+}
+
+func (c *CircuitSet) ConnectCircuits(left support.Point3, right support.Point3) error {
+	fromCircuit, fromOk := c.circuitMap.Get(right)
+	toCircuit, toOk := c.circuitMap.Get(left)
+
+	if !fromOk || !toOk {
+		return fmt.Errorf("cannot connect %v and %v: received a point I don't know about", left, right)
+	}
+
+	for k, v := range c.circuitMap.All() {
+		if v == fromCircuit {
+			// Only ever updates existing boxes, so the order is untouched and it's safe to carry on iterating
+			c.circuitMap.Set(k, toCircuit)
+		}
+	}
+
+	return nil
+}
+
+func (c *CircuitSet) LargestCircuits(n int) ([]int, error) {
+	sizes := support.CountBySeq(c.circuitMap.Values(), func(circuitNo int) int { return circuitNo })
+	if sizes.Len() < n {
+		return nil, fmt.Errorf("wanted the %d largest circuits but there are only %d", n, sizes.Len())
+	}
+
+	return support.TopKSeq(sizes.Values(), n, cmp.Compare[int]), nil
+}
+
+func (c *CircuitSet) IsThereOnlyOneCircuitYet() bool {
+	circuit := -1
+
+	for _, v := range c.circuitMap.All() {
+		if circuit == -1 {
+			circuit = v
+		}
+
+		if v != circuit {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parsePositions(input string) ([]support.Point3, error) {
+	return support.DecodeLines[support.Point3](input, "{X},{Y},{Z}")
+}
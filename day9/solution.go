@@ -1,29 +1,32 @@
-package main
+package day9
 
 import (
+	"advent-of-code-2025/aoc"
 	"advent-of-code-2025/support"
+	"cmp"
+	"context"
+	_ "embed"
+	"errors"
 	"fmt"
-	"maps"
-	"math"
 	"slices"
-	"strings"
 )
 
-func main() {
-	points := support.Map(
-		strings.Split(support.LoadInput(), "\n"),
-		func(line string) support.Point2 {
-			coords := support.SliceOfNumericStringsToSliceOfInts(strings.Split(line, ","))
-			if len(coords) != 2 {
-				panic("Expected co-ordinate with two points")
-			}
+//go:embed testdata/example.txt
+var exampleInput string
 
-			return support.Point2{X: coords[0], Y: coords[1]}
+func init() {
+	aoc.Register(9, aoc.Solver[[]support.Point2]{
+		Parse:   parsePoints,
+		PartOne: func(_ context.Context, points []support.Point2) (int, error) { return partOne(points), nil },
+		PartTwo: partTwo,
+		Examples: []aoc.Example[[]support.Point2]{
+			{Input: exampleInput, PartOne: 50, PartTwo: 24},
 		},
-	)
+	})
+}
 
-	fmt.Println(partOne(points))
-	fmt.Println(partTwo(points))
+func parsePoints(input string) ([]support.Point2, error) {
+	return support.DecodeLines[support.Point2](input, "{X},{Y}")
 }
 
 func partOne(points []support.Point2) int {
@@ -53,29 +56,39 @@ type pointPair struct {
 }
 
 func (p *pointPair) Area() int {
+	size := p.from.Sub(p.to)
+
 	// +1 because the edges are included in the area
-	return int(math.Abs(float64(p.from.X-p.to.X))+1) * int(math.Abs(float64(p.from.Y-p.to.Y))+1)
+	return (support.AbsInt(size.X) + 1) * (support.AbsInt(size.Y) + 1)
 }
 
-func partTwo(points []support.Point2) int {
-	horizontalWalls, verticalWalls := collectWalls(points)
+func partTwo(ctx context.Context, points []support.Point2) (int, error) {
+	horizontalWalls, verticalWalls, err := collectWalls(points)
+	if err != nil {
+		return 0, err
+	}
 
 	for _, pair := range collectPointPairs(points) {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
 		// The first valid rectangle formed must be the largest
 		if validRectangle(pair, horizontalWalls, verticalWalls) {
-			return pair.Area()
+			return pair.Area(), nil
 		}
 	}
 
-	return -1
+	return 0, errors.New("no pair of points forms a rectangle inside the shape")
 }
 
-// Returns a slice of each unique pair of points, ordered by the area of the rectange they form, largest first.
+// Returns a slice of each unique pair of points, ordered by the area of the rectange they form, largest first. Pairs
+// with the same area are kept in the order their points appear in the input.
 func collectPointPairs(points []support.Point2) []pointPair {
-	uniquePairs := support.NewSet[pointPair]()
+	uniquePairs := support.NewOrderedSet[pointPair]()
 
-	for _, left := range points {
-		for _, right := range points {
+	for i, left := range points {
+		for _, right := range points[i+1:] {
 			if left == right {
 				continue
 			}
@@ -90,17 +103,9 @@ func collectPointPairs(points []support.Point2) []pointPair {
 		}
 	}
 
-	pairs := slices.Collect(maps.Keys(uniquePairs))
-	slices.SortFunc(pairs, func(a, b pointPair) int {
-		if b.Area() < a.Area() {
-			return -1
-		}
-
-		if b.Area() > a.Area() {
-			return 1
-		}
-
-		return 0
+	pairs := slices.Collect(uniquePairs.All())
+	slices.SortStableFunc(pairs, func(a, b pointPair) int {
+		return cmp.Compare(b.Area(), a.Area())
 	})
 
 	return pairs
@@ -116,7 +121,7 @@ func aSmallerThanB(a, b support.Point2) bool {
 }
 
 // Returns two slices representing the horizontal and vertical "walls" of the shape formed by subsequent point pairs.
-func collectWalls(points []support.Point2) ([]pointPair, []pointPair) {
+func collectWalls(points []support.Point2) ([]pointPair, []pointPair, error) {
 	horizontalWalls := make([]pointPair, 0)
 	verticalWalls := make([]pointPair, 0)
 
@@ -135,25 +140,28 @@ func collectWalls(points []support.Point2) ([]pointPair, []pointPair) {
 		} else if curr.X == next.X {
 			verticalWalls = append(verticalWalls, pointPair{from: curr, to: next})
 		} else {
-			panic("Both x and y changed between subsequent points")
+			return nil, nil, fmt.Errorf("both x and y changed between %v on line %d and %v", curr, i+1, next)
 		}
 	}
 
-	return horizontalWalls, verticalWalls
+	return horizontalWalls, verticalWalls, nil
 }
 
 // Determines if the rectangle formed by `pair` is valid by making sure it doesn't intersect any walls of the shape.
 func validRectangle(pair pointPair, horizontalWalls []pointPair, verticalWalls []pointPair) bool {
-	startX := support.MinInt(pair.from.X, pair.to.X)
-	endX := support.MaxInt(pair.from.X, pair.to.X)
-	startY := support.MinInt(pair.from.Y, pair.to.Y)
-	endY := support.MaxInt(pair.from.Y, pair.to.Y)
+	topLeft := support.Point2{X: support.MinInt(pair.from.X, pair.to.X), Y: support.MinInt(pair.from.Y, pair.to.Y)}
+	bottomRight := support.Point2{X: support.MaxInt(pair.from.X, pair.to.X), Y: support.MaxInt(pair.from.Y, pair.to.Y)}
 
 	// We check the space inside the rectangle rather than the rectangle itself because the edges of the rectangle may
 	// be formed of multiple walls, and so "intersects" walls that do not interrupt the rectangle.
+	innerTopLeft := topLeft.Step(support.SouthEast)
+	innerBottomRight := bottomRight.Step(support.NorthWest)
+	innerTopRight := support.Point2{X: innerBottomRight.X, Y: innerTopLeft.Y}
+	innerBottomLeft := support.Point2{X: innerTopLeft.X, Y: innerBottomRight.Y}
+
 	topAndBottom := []pointPair{
-		{from: support.Point2{X: startX + 1, Y: startY + 1}, to: support.Point2{X: endX - 1, Y: startY + 1}},
-		{from: support.Point2{X: startX + 1, Y: endY - 1}, to: support.Point2{X: endX - 1, Y: endY - 1}},
+		{from: innerTopLeft, to: innerTopRight},
+		{from: innerBottomLeft, to: innerBottomRight},
 	}
 
 	for _, a := range topAndBottom {
@@ -165,8 +173,8 @@ func validRectangle(pair pointPair, horizontalWalls []pointPair, verticalWalls [
 	}
 
 	leftAndRight := []pointPair{
-		{from: support.Point2{X: startX + 1, Y: startY + 1}, to: support.Point2{X: startX + 1, Y: endY - 1}},
-		{from: support.Point2{X: endX - 1, Y: startY + 1}, to: support.Point2{X: endX - 1, Y: endY - 1}},
+		{from: innerTopLeft, to: innerBottomLeft},
+		{from: innerTopRight, to: innerBottomRight},
 	}
 
 	for _, a := range leftAndRight {
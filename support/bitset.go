@@ -0,0 +1,177 @@
+package support
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+const wordSize = 64
+
+// A Bitset is a fixed-width set of bits, numbered from 0. Unlike an int bitfield it can be as wide as needed. Set,
+// Clear, ClearAll and XorWith change the bitset in place; everything else leaves it untouched.
+type Bitset struct {
+	width int
+	words []uint64
+}
+
+func NewBitset(width int) Bitset {
+	return Bitset{width: width, words: make([]uint64, (width+wordSize-1)/wordSize)}
+}
+
+// Parse a pattern like ".##." where # is a set bit. The first character is bit 0.
+func ParseBitset(pattern string) (Bitset, error) {
+	b := NewBitset(len(pattern))
+
+	for i, c := range pattern {
+		switch c {
+		case '#':
+			b.Set(i)
+		case '.':
+		default:
+			return Bitset{}, &ParseError{Column: i + 1, Text: pattern, Err: fmt.Errorf("expected . or #, got %q", c)}
+		}
+	}
+
+	return b, nil
+}
+
+func (b Bitset) Width() int {
+	return b.width
+}
+
+func (b Bitset) checkIndex(i int) {
+	if i < 0 || i >= b.width {
+		panic(fmt.Sprintf("support: bit %d out of range for Bitset of width %d", i, b.width))
+	}
+}
+
+func (b Bitset) Set(i int) {
+	b.checkIndex(i)
+	b.words[i/wordSize] |= 1 << (i % wordSize)
+}
+
+func (b Bitset) Clear(i int) {
+	b.checkIndex(i)
+	b.words[i/wordSize] &^= 1 << (i % wordSize)
+}
+
+// Clear every bit, e.g. to reuse the bitset rather than allocate a new one.
+func (b Bitset) ClearAll() {
+	clear(b.words)
+}
+
+func (b Bitset) Test(i int) bool {
+	b.checkIndex(i)
+
+	return b.words[i/wordSize]&(1<<(i%wordSize)) != 0
+}
+
+func (b Bitset) Clone() Bitset {
+	return Bitset{width: b.width, words: append([]uint64(nil), b.words...)}
+}
+
+// Combine two bitsets word by word. The result is as wide as the wider of the two.
+func (b Bitset) combine(other Bitset, op func(x, y uint64) uint64) Bitset {
+	result := NewBitset(max(b.width, other.width))
+
+	for i := range result.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+
+		if i < len(other.words) {
+			y = other.words[i]
+		}
+
+		result.words[i] = op(x, y)
+	}
+
+	return result
+}
+
+func (b Bitset) Xor(other Bitset) Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// As Xor, but in place. A bitset can't grow in place, so other mustn't be wider.
+func (b Bitset) XorWith(other Bitset) {
+	if other.width > b.width {
+		panic(fmt.Sprintf("support: can't XOR a Bitset of width %d into one of width %d", other.width, b.width))
+	}
+
+	for i, word := range other.words {
+		b.words[i] ^= word
+	}
+}
+
+func (b Bitset) And(other Bitset) Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+func (b Bitset) Or(other Bitset) Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Count the set bits.
+func (b Bitset) Count() int {
+	count := 0
+
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+
+	return count
+}
+
+// Iterate over the indexes of the set bits in ascending order.
+func (b Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, word := range b.words {
+			for word != 0 {
+				if !yield(i*wordSize + bits.TrailingZeros64(word)) {
+					return
+				}
+
+				// Clear the lowest set bit
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// Equal exactly when the keys are, without building them.
+func (b Bitset) Equal(other Bitset) bool {
+	return b.width == other.width && slices.Equal(b.words, other.words)
+}
+
+// Return a comparable value that is equal for two bitsets exactly when they are, for use as a map key.
+func (b Bitset) Key() string {
+	key := make([]byte, 0, 8+len(b.words)*8)
+	key = binary.LittleEndian.AppendUint64(key, uint64(b.width))
+
+	for _, word := range b.words {
+		key = binary.LittleEndian.AppendUint64(key, word)
+	}
+
+	return string(key)
+}
+
+// Format the bitset in the same .# form ParseBitset accepts.
+func (b Bitset) String() string {
+	var sb strings.Builder
+
+	for i := range b.width {
+		if b.Test(i) {
+			sb.WriteByte('#')
+		} else {
+			sb.WriteByte('.')
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,172 @@
+package support
+
+import "iter"
+
+// The iterators here yield the same slice every time, overwritten for each step, so nothing is allocated per item.
+// Clone it with slices.Clone to keep it beyond the current loop iteration.
+
+// Iterate over every way of choosing k of items, ignoring order, in lexicographic order of position. e.g. choosing 2
+// of [a b c] gives [a b], [a c], [b c].
+func Combinations[T any](items []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if k < 0 || k > len(items) {
+			return
+		}
+
+		indexes := Range(0, k)
+		buffer := make([]T, k)
+
+		for {
+			for i, index := range indexes {
+				buffer[i] = items[index]
+			}
+
+			if !yield(buffer) {
+				return
+			}
+
+			// Find the rightmost index that can still move right, move it, and reset everything after it to follow on
+			// straight after
+			i := k - 1
+			for i >= 0 && indexes[i] == len(items)-k+i {
+				i--
+			}
+
+			if i < 0 {
+				return
+			}
+
+			indexes[i]++
+			for j := i + 1; j < k; j++ {
+				indexes[j] = indexes[j-1] + 1
+			}
+		}
+	}
+}
+
+// As Combinations, but each item can be chosen more than once. e.g. choosing 2 of [a b] gives [a a], [a b], [b b].
+func CombinationsWithReplacement[T any](items []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if k < 0 || (len(items) == 0 && k > 0) {
+			return
+		}
+
+		indexes := make([]int, k)
+		buffer := make([]T, k)
+
+		for {
+			for i, index := range indexes {
+				buffer[i] = items[index]
+			}
+
+			if !yield(buffer) {
+				return
+			}
+
+			i := k - 1
+			for i >= 0 && indexes[i] == len(items)-1 {
+				i--
+			}
+
+			if i < 0 {
+				return
+			}
+
+			indexes[i]++
+			for j := i + 1; j < k; j++ {
+				indexes[j] = indexes[i]
+			}
+		}
+	}
+}
+
+// Iterate over every ordered arrangement of k of items, in lexicographic order of position. e.g. arranging 2 of
+// [a b c] gives [a b], [a c], [b a], [b c], [c a], [c b].
+func Permutations[T any](items []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if k < 0 || k > len(items) {
+			return
+		}
+
+		buffer := make([]T, k)
+		used := make([]bool, len(items))
+
+		// Fill buffer from position pos onwards, returning false once the consumer has stopped
+		var fill func(pos int) bool
+		fill = func(pos int) bool {
+			if pos == k {
+				return yield(buffer)
+			}
+
+			for i, item := range items {
+				if used[i] {
+					continue
+				}
+
+				used[i] = true
+				buffer[pos] = item
+
+				if !fill(pos + 1) {
+					return false
+				}
+
+				used[i] = false
+			}
+
+			return true
+		}
+
+		fill(0)
+	}
+}
+
+// Iterate over every way of picking one item from each list, varying the last list fastest. e.g. [a b] and [x y]
+// give [a x], [a y], [b x], [b y].
+func CartesianProduct[T any](lists ...[]T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for _, list := range lists {
+			if len(list) == 0 {
+				return
+			}
+		}
+
+		indexes := make([]int, len(lists))
+		buffer := make([]T, len(lists))
+
+		for {
+			for i, index := range indexes {
+				buffer[i] = lists[i][index]
+			}
+
+			if !yield(buffer) {
+				return
+			}
+
+			// Count up like an odometer
+			i := len(lists) - 1
+			for i >= 0 && indexes[i] == len(lists[i])-1 {
+				indexes[i] = 0
+				i--
+			}
+
+			if i < 0 {
+				return
+			}
+
+			indexes[i]++
+		}
+	}
+}
+
+// Iterate over every subset of items, smallest first, starting with the empty set.
+func PowerSet[T any](items []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for k := 0; k <= len(items); k++ {
+			for subset := range Combinations(items, k) {
+				if !yield(subset) {
+					return
+				}
+			}
+		}
+	}
+}
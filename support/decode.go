@@ -0,0 +1,312 @@
+package support
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// Build a function that decodes a line laid out like pattern into a T, for use with ParseLines and ParseSection.
+//
+// The pattern names T's fields in braces with literal text between them, e.g. "{X},{Y}" for a Point2. Use {{ and }}
+// for literal braces. Fields can be strings, integers, anything implementing encoding.TextUnmarshaler, or slices of
+// those (including nested slices). A slice field's `sep` tag gives the separator for each level of nesting, outermost
+// first, with whitespace used once they run out. Its `trim` tag gives characters to strip from around each element of
+// the outermost level, e.g. `sep:" ," trim:"()"` decodes "(3) (1,3)" into [][]int{{3}, {1, 3}}.
+//
+// Like regexp.MustCompile, this panics if the pattern doesn't fit T, as that's a mistake in the code not the input.
+func Decoder[T any](pattern string) func(string) (T, error) {
+	typ := reflect.TypeFor[T]()
+
+	tokens, err := parsePattern(pattern, typ)
+	if err != nil {
+		panic(fmt.Sprintf("support: Decoder[%s](%q): %v", typ, pattern, err))
+	}
+
+	return func(line string) (T, error) {
+		var out T
+
+		if err := decodeLine(reflect.ValueOf(&out).Elem(), line, tokens); err != nil {
+			return out, err
+		}
+
+		return out, nil
+	}
+}
+
+// Decode every line of input into a T laid out like pattern. See Decoder for the pattern format.
+func DecodeLines[T any](input, pattern string) ([]T, error) {
+	return ParseLines(Lines(input), Decoder[T](pattern))
+}
+
+// A patternToken is either a run of literal text, or a field to decode whatever text comes before the next literal.
+type patternToken struct {
+	literal string
+	field   []int // Index of the struct field as used by reflect.Value.FieldByIndex, or nil for a literal
+	name    string
+	seps    string
+	trim    string
+}
+
+func parsePattern(pattern string, typ reflect.Type) ([]patternToken, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", typ)
+	}
+
+	tokens := make([]patternToken, 0)
+	var literal strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "{{"), strings.HasPrefix(pattern[i:], "}}"):
+			literal.WriteByte(pattern[i])
+			i++
+
+		case pattern[i] == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed { at column %d", i+1)
+			}
+
+			name := pattern[i+1 : i+end]
+
+			field, ok := typ.FieldByName(name)
+			if !ok || !field.IsExported() {
+				return nil, fmt.Errorf("%s has no exported field %q", typ, name)
+			}
+
+			if err := checkDecodable(field.Type); err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+
+			// Without text between them there'd be no way to tell where one field ends and the next begins
+			if literal.Len() == 0 && len(tokens) > 0 {
+				return nil, fmt.Errorf("field %s must be separated from the field before it", name)
+			}
+
+			if literal.Len() > 0 {
+				tokens = append(tokens, patternToken{literal: literal.String()})
+				literal.Reset()
+			}
+
+			tokens = append(tokens, patternToken{
+				field: field.Index,
+				name:  name,
+				seps:  field.Tag.Get("sep"),
+				trim:  field.Tag.Get("trim"),
+			})
+
+			i += end
+
+		case pattern[i] == '}':
+			return nil, fmt.Errorf("unmatched } at column %d", i+1)
+
+		default:
+			literal.WriteByte(pattern[i])
+		}
+	}
+
+	if literal.Len() > 0 {
+		tokens = append(tokens, patternToken{literal: literal.String()})
+	}
+
+	return tokens, nil
+}
+
+func checkDecodable(typ reflect.Type) error {
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nil
+	case reflect.Slice:
+		return checkDecodable(typ.Elem())
+	default:
+		return fmt.Errorf("cannot decode into %s", typ)
+	}
+}
+
+// Match line against the pattern's tokens, decoding each field from the text up to the literal that follows it. The
+// last literal is matched against the end of the line so fields can contain text that looks like it.
+func decodeLine(out reflect.Value, line string, tokens []patternToken) error {
+	pos := 0
+
+	for i, token := range tokens {
+		if token.field == nil {
+			if !strings.HasPrefix(line[pos:], token.literal) {
+				return &ParseError{Column: pos + 1, Text: line, Err: fmt.Errorf("expected %q", token.literal)}
+			}
+
+			pos += len(token.literal)
+			continue
+		}
+
+		end := len(line)
+
+		if i+1 < len(tokens) {
+			next := tokens[i+1].literal
+
+			if i+2 == len(tokens) {
+				if !strings.HasSuffix(line[pos:], next) {
+					return &ParseError{
+						Column: len(line) + 1,
+						Text:   line,
+						Err:    fmt.Errorf("expected line to end with %q after %s", next, token.name),
+					}
+				}
+
+				end = len(line) - len(next)
+			} else {
+				offset := strings.Index(line[pos:], next)
+				if offset == -1 {
+					return &ParseError{
+						Column: pos + 1,
+						Text:   line,
+						Err:    fmt.Errorf("expected %q after %s", next, token.name),
+					}
+				}
+
+				end = pos + offset
+			}
+		}
+
+		if err := decodeValue(out.FieldByIndex(token.field), line[pos:end], pos, token.seps, token.trim); err != nil {
+			err.Text = line
+			err.Err = fmt.Errorf("%s: %w", token.name, err.Err)
+
+			return err
+		}
+
+		pos = end
+	}
+
+	if pos != len(line) {
+		return &ParseError{Column: pos + 1, Text: line, Err: fmt.Errorf("unexpected %q at end of line", line[pos:])}
+	}
+
+	return nil
+}
+
+// Decode text, which starts at the 0-based offset start within the line, into v. Errors carry the column of the
+// offending text within the line.
+func decodeValue(v reflect.Value, text string, start int, seps, trim string) *ParseError {
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	start += len(text) - len(trimmed)
+	text = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(text)); err != nil {
+			return &ParseError{Column: start + 1, Err: err}
+		}
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return &ParseError{Column: start + 1, Err: numberError(text, v.Type(), err)}
+		}
+
+		v.SetInt(value)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return &ParseError{Column: start + 1, Err: numberError(text, v.Type(), err)}
+		}
+
+		v.SetUint(value)
+
+	case reflect.Slice:
+		sep, innerSeps := "", ""
+		if seps != "" {
+			_, size := utf8.DecodeRuneInString(seps)
+			sep, innerSeps = seps[:size], seps[size:]
+		}
+
+		elements := reflect.MakeSlice(v.Type(), 0, 0)
+
+		for _, element := range splitWithOffsets(text, sep) {
+			inner := strings.TrimLeft(element.text, trim)
+			innerStart := start + element.start + len(element.text) - len(inner)
+
+			decoded := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeValue(decoded, strings.TrimRight(inner, trim), innerStart, innerSeps, ""); err != nil {
+				return err
+			}
+
+			elements = reflect.Append(elements, decoded)
+		}
+
+		v.Set(elements)
+	}
+
+	return nil
+}
+
+func numberError(text string, typ reflect.Type, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("%q is out of range for %s", text, typ)
+	}
+
+	return fmt.Errorf("%q is not an integer", text)
+}
+
+type textSpan struct {
+	start int // 0-based offset of text within whatever was split
+	text  string
+}
+
+// Split text on sep, or on runs of whitespace if sep is empty, keeping track of where each part starts. Empty text
+// gives no parts at all, so an empty list decodes to an empty slice.
+func splitWithOffsets(text, sep string) []textSpan {
+	spans := make([]textSpan, 0)
+
+	if text == "" {
+		return spans
+	}
+
+	if sep == "" {
+		for i := 0; i < len(text); {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			if unicode.IsSpace(r) {
+				i += size
+				continue
+			}
+
+			end := strings.IndexFunc(text[i:], unicode.IsSpace)
+			if end == -1 {
+				end = len(text) - i
+			}
+
+			spans = append(spans, textSpan{start: i, text: text[i : i+end]})
+			i += end
+		}
+
+		return spans
+	}
+
+	start := 0
+	for _, part := range strings.Split(text, sep) {
+		spans = append(spans, textSpan{start: start, text: part})
+		start += len(part) + len(sep)
+	}
+
+	return spans
+}
@@ -0,0 +1,395 @@
+package support
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Most of these come in two flavours: one over a slice and one, with a Seq suffix, over an iter.Seq. The slice versions
+// allocate at most their result; the Seq versions are lazy where they can be and allocate nothing per item.
+
+// A Pair holds two values of possibly different types, e.g. one from each side of Zip.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+func Map[I any, O any](input []I, fn func(I) O) []O {
+	output := make([]O, 0, len(input))
+
+	for _, item := range input {
+		output = append(output, fn(item))
+	}
+
+	return output
+}
+
+func MapSeq[I any, O any](seq iter.Seq[I], fn func(I) O) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		for item := range seq {
+			if !yield(fn(item)) {
+				return
+			}
+		}
+	}
+}
+
+// Return the items that keep returns true for, in order.
+func Filter[T any](items []T, keep func(T) bool) []T {
+	output := make([]T, 0)
+
+	for _, item := range items {
+		if keep(item) {
+			output = append(output, item)
+		}
+	}
+
+	return output
+}
+
+func FilterSeq[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if keep(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Fold items into a single value, starting from initial and combining each item in turn with fn.
+func Reduce[T, A any](items []T, initial A, fn func(A, T) A) A {
+	return ReduceSeq(slices.Values(items), initial, fn)
+}
+
+func ReduceSeq[T, A any](seq iter.Seq[T], initial A, fn func(A, T) A) A {
+	acc := initial
+
+	for item := range seq {
+		acc = fn(acc, item)
+	}
+
+	return acc
+}
+
+// Group items by key. Groups are in the order their key was first seen, and items keep their order within a group.
+func GroupBy[T any, K comparable](items []T, key func(T) K) *OrderedMap[K, []T] {
+	return GroupBySeq(slices.Values(items), key)
+}
+
+func GroupBySeq[T any, K comparable](seq iter.Seq[T], key func(T) K) *OrderedMap[K, []T] {
+	groups := NewOrderedMap[K, []T]()
+
+	for item := range seq {
+		k := key(item)
+		group, _ := groups.Get(k)
+		groups.Set(k, append(group, item))
+	}
+
+	return groups
+}
+
+// Count how many items there are for each key, in the order each key was first seen.
+func CountBy[T any, K comparable](items []T, key func(T) K) *OrderedMap[K, int] {
+	return CountBySeq(slices.Values(items), key)
+}
+
+func CountBySeq[T any, K comparable](seq iter.Seq[T], key func(T) K) *OrderedMap[K, int] {
+	counts := NewOrderedMap[K, int]()
+
+	for item := range seq {
+		k := key(item)
+		count, _ := counts.Get(k)
+		counts.Set(k, count+1)
+	}
+
+	return counts
+}
+
+// Split items into consecutive chunks of size, the last of which may be shorter. The chunks share items' storage.
+// Panics if size is less than 1.
+func Chunk[T any](items []T, size int) [][]T {
+	if size < 1 {
+		panic("support: chunk size must be at least 1")
+	}
+
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		chunks = append(chunks, items[start:end:end])
+	}
+
+	return chunks
+}
+
+// As Chunk, but the same slice is yielded every time, overwritten for each chunk.
+func ChunkSeq[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("support: chunk size must be at least 1")
+	}
+
+	return func(yield func([]T) bool) {
+		buffer := make([]T, 0, size)
+
+		for item := range seq {
+			buffer = append(buffer, item)
+
+			if len(buffer) == size {
+				if !yield(buffer) {
+					return
+				}
+
+				buffer = buffer[:0]
+			}
+		}
+
+		if len(buffer) > 0 {
+			yield(buffer)
+		}
+	}
+}
+
+// Return every run of size consecutive items, e.g. windows of 2 over [a b c] are [a b], [b c]. The windows share
+// items' storage. Panics if size is less than 1.
+func Windows[T any](items []T, size int) [][]T {
+	if size < 1 {
+		panic("support: window size must be at least 1")
+	}
+
+	windows := make([][]T, 0, max(len(items)-size+1, 0))
+
+	for start := 0; start+size <= len(items); start++ {
+		windows = append(windows, items[start:start+size:start+size])
+	}
+
+	return windows
+}
+
+// As Windows, but the same slice is yielded every time, overwritten for each window.
+func WindowsSeq[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("support: window size must be at least 1")
+	}
+
+	return func(yield func([]T) bool) {
+		buffer := make([]T, 0, size)
+
+		for item := range seq {
+			if len(buffer) == size {
+				copy(buffer, buffer[1:])
+				buffer[size-1] = item
+			} else {
+				buffer = append(buffer, item)
+			}
+
+			if len(buffer) == size && !yield(buffer) {
+				return
+			}
+		}
+	}
+}
+
+// Pair up items from a and b by position, stopping at the end of the shorter one.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	pairs := make([]Pair[A, B], 0, min(len(a), len(b)))
+
+	for i := range min(len(a), len(b)) {
+		pairs = append(pairs, Pair[A, B]{First: a[i], Second: b[i]})
+	}
+
+	return pairs
+}
+
+func ZipSeq[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+
+		for itemA := range a {
+			itemB, ok := nextB()
+			if !ok || !yield(itemA, itemB) {
+				return
+			}
+		}
+	}
+}
+
+// Pair each item with its position.
+func Enumerate[T any](items []T) []Pair[int, T] {
+	pairs := make([]Pair[int, T], 0, len(items))
+
+	for i, item := range items {
+		pairs = append(pairs, Pair[int, T]{First: i, Second: item})
+	}
+
+	return pairs
+}
+
+func EnumerateSeq[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+
+		for item := range seq {
+			if !yield(i, item) {
+				return
+			}
+
+			i++
+		}
+	}
+}
+
+// Return the k largest items according to cmp, largest first. Items that compare equal keep their input order. Only
+// k items are held at once, so it's O(n log k) and cheap for small k over long inputs.
+func TopK[T any](items []T, k int, cmp func(a, b T) int) []T {
+	return TopKSeq(slices.Values(items), k, cmp)
+}
+
+func TopKSeq[T any](seq iter.Seq[T], k int, cmp func(a, b T) int) []T {
+	if k <= 0 {
+		return []T{}
+	}
+
+	type entry struct {
+		item  T
+		index int
+	}
+
+	// Order entries worst first: smallest by cmp, and latest among equals
+	less := func(a, b entry) int {
+		if c := cmp(a.item, b.item); c != 0 {
+			return c
+		}
+
+		return b.index - a.index
+	}
+
+	// A min-heap of the best k so far, with the worst of them at the root ready to be replaced
+	heap := make([]entry, 0, k)
+
+	siftDown := func(i int) {
+		for {
+			smallest := i
+
+			if left := 2*i + 1; left < len(heap) && less(heap[left], heap[smallest]) < 0 {
+				smallest = left
+			}
+
+			if right := 2*i + 2; right < len(heap) && less(heap[right], heap[smallest]) < 0 {
+				smallest = right
+			}
+
+			if smallest == i {
+				return
+			}
+
+			heap[i], heap[smallest] = heap[smallest], heap[i]
+			i = smallest
+		}
+	}
+
+	index := 0
+
+	for item := range seq {
+		e := entry{item: item, index: index}
+		index++
+
+		if len(heap) < k {
+			heap = append(heap, e)
+
+			for i := len(heap) - 1; i > 0 && less(heap[i], heap[(i-1)/2]) < 0; i = (i - 1) / 2 {
+				heap[i], heap[(i-1)/2] = heap[(i-1)/2], heap[i]
+			}
+
+			continue
+		}
+
+		if less(e, heap[0]) > 0 {
+			heap[0] = e
+			siftDown(0)
+		}
+	}
+
+	slices.SortFunc(heap, func(a, b entry) int { return less(b, a) })
+
+	return Map(heap, func(e entry) T { return e.item })
+}
+
+// Return the item with the smallest key, or false if there are no items. The first one wins a tie.
+func MinBy[T any, K cmp.Ordered](items []T, key func(T) K) (T, bool) {
+	return MinBySeq(slices.Values(items), key)
+}
+
+func MinBySeq[T any, K cmp.Ordered](seq iter.Seq[T], key func(T) K) (T, bool) {
+	return bestBy(seq, key, func(candidate, best K) bool { return candidate < best })
+}
+
+// Return the item with the largest key, or false if there are no items. The first one wins a tie.
+func MaxBy[T any, K cmp.Ordered](items []T, key func(T) K) (T, bool) {
+	return MaxBySeq(slices.Values(items), key)
+}
+
+func MaxBySeq[T any, K cmp.Ordered](seq iter.Seq[T], key func(T) K) (T, bool) {
+	return bestBy(seq, key, func(candidate, best K) bool { return candidate > best })
+}
+
+func bestBy[T any, K cmp.Ordered](seq iter.Seq[T], key func(T) K, better func(candidate, best K) bool) (T, bool) {
+	var best T
+	var bestKey K
+	found := false
+
+	for item := range seq {
+		k := key(item)
+
+		if !found || better(k, bestKey) {
+			best, bestKey, found = item, k, true
+		}
+	}
+
+	return best, found
+}
+
+// Given n, return a slice containing min..<max, which is empty if min >= max
+func Range(min, max int) []int {
+	if min >= max {
+		return []int{}
+	}
+
+	r := make([]int, 0, max-min)
+
+	for i := min; i < max; i++ {
+		r = append(r, i)
+	}
+
+	return r
+}
+
+// Return start, start+step, ... up to but not including end. A negative step counts down. Panics if step is 0.
+func RangeStep[T Integer](start, end, step T) []T {
+	return slices.Collect(RangeSeq(start, end, step))
+}
+
+func RangeSeq[T Integer](start, end, step T) iter.Seq[T] {
+	if step == 0 {
+		panic("support: range step must not be 0")
+	}
+
+	return func(yield func(T) bool) {
+		for i := start; (step > 0 && i < end) || (step < 0 && i > end); {
+			if !yield(i) {
+				return
+			}
+
+			// Stepping past the largest or smallest value of T wraps around to the other side of end, which would
+			// carry on forever, so stop if the step didn't move the way it should
+			next := i + step
+			if (next > i) != (step > 0) {
+				return
+			}
+
+			i = next
+		}
+	}
+}
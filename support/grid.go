@@ -0,0 +1,182 @@
+package support
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// A Grid is a rectangular 2D grid of cells addressed by Point2, with {X: 0, Y: 0} at the top left.
+type Grid[T any] struct {
+	width, height int
+	cells         []T // Row by row, top to bottom
+}
+
+func NewGrid[T any](width, height int) *Grid[T] {
+	return &Grid[T]{width: width, height: height, cells: make([]T, width*height)}
+}
+
+// Parse input into a grid of runes, one row per line. Every line must be the same length as the first.
+func ParseGrid(input string) (*Grid[rune], error) {
+	lines := Lines(input)
+	if len(lines) == 0 || lines[0] == "" {
+		return nil, errors.New("grid is empty")
+	}
+
+	width := len([]rune(lines[0]))
+	grid := NewGrid[rune](width, len(lines))
+
+	for y, line := range lines {
+		row := []rune(line)
+
+		if len(row) != width {
+			return nil, &ParseError{
+				Line: y + 1,
+				Text: line,
+				Err:  fmt.Errorf("expected %d cells like the first line, got %d", width, len(row)),
+			}
+		}
+
+		copy(grid.Row(y), row)
+	}
+
+	return grid, nil
+}
+
+// Build a new grid by applying fn to every cell of g.
+func MapGrid[I, O any](g *Grid[I], fn func(Point2, I) O) *Grid[O] {
+	mapped := NewGrid[O](g.width, g.height)
+
+	for p, cell := range g.All() {
+		mapped.cells[g.index(p)] = fn(p, cell)
+	}
+
+	return mapped
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+func (g *Grid[T]) InBounds(p Point2) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+func (g *Grid[T]) index(p Point2) int {
+	return p.Y*g.width + p.X
+}
+
+// Return the cell at p, or false if p is off the grid.
+func (g *Grid[T]) Get(p Point2) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+
+	return g.cells[g.index(p)], true
+}
+
+// Set the cell at p, returning false and leaving the grid untouched if p is off the grid.
+func (g *Grid[T]) Set(p Point2, value T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+
+	g.cells[g.index(p)] = value
+
+	return true
+}
+
+// Return row y. The slice shares the grid's storage, so writing to it changes the grid.
+func (g *Grid[T]) Row(y int) []T {
+	return g.cells[y*g.width : (y+1)*g.width : (y+1)*g.width]
+}
+
+// Iterate over every cell, row by row from the top left.
+func (g *Grid[T]) All() iter.Seq2[Point2, T] {
+	return func(yield func(Point2, T) bool) {
+		for i, cell := range g.cells {
+			if !yield(Point2{X: i % g.width, Y: i / g.width}, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over the cells next to p that are on the grid, using the given offsets.
+func (g *Grid[T]) neighbours(p Point2, offsets []Point2) iter.Seq2[Point2, T] {
+	return func(yield func(Point2, T) bool) {
+		for _, offset := range offsets {
+			neighbour := p.Add(offset)
+
+			if cell, ok := g.Get(neighbour); ok && !yield(neighbour, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over the up to 4 cells directly above, below, left and right of p.
+func (g *Grid[T]) Neighbours4(p Point2) iter.Seq2[Point2, T] {
+	return g.neighbours(p, OrthogonalNeighbours)
+}
+
+// Iterate over the up to 8 cells surrounding p, including diagonals.
+func (g *Grid[T]) Neighbours8(p Point2) iter.Seq2[Point2, T] {
+	return g.neighbours(p, AllNeighbours)
+}
+
+// Return the position of the first cell, row by row, that matches, or false if there isn't one.
+func (g *Grid[T]) Find(match func(T) bool) (Point2, bool) {
+	for p, cell := range g.All() {
+		if match(cell) {
+			return p, true
+		}
+	}
+
+	return Point2{}, false
+}
+
+// Return the positions of every cell that matches, row by row.
+func (g *Grid[T]) FindAll(match func(T) bool) []Point2 {
+	found := make([]Point2, 0)
+
+	for p, cell := range g.All() {
+		if match(cell) {
+			found = append(found, p)
+		}
+	}
+
+	return found
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{width: g.width, height: g.height, cells: slices.Clone(g.cells)}
+}
+
+// Render the grid one row per line. Rune cells are drawn as they are; anything else is formatted with fmt.
+func (g *Grid[T]) String() string {
+	var sb strings.Builder
+
+	for y := range g.height {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+
+		for _, cell := range g.Row(y) {
+			if r, ok := any(cell).(rune); ok {
+				sb.WriteRune(r)
+			} else {
+				fmt.Fprint(&sb, cell)
+			}
+		}
+	}
+
+	return sb.String()
+}
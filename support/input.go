@@ -1,21 +1,140 @@
 package support
 
 import (
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"runtime"
+	"strconv"
+	"strings"
 )
 
-func LoadInput() string {
-	_, file, _, ok := runtime.Caller(1)
-	if !ok {
-		panic("Could not determine running directory!")
+// Placeholder in input paths that is replaced with the day number, e.g. "inputs/day{day}.txt"
+const DayPlaceholder = "{day}"
+
+// An InputSource supplies the puzzle input for a given day.
+type InputSource interface {
+	Load(day int) (string, error)
+	// A short description of where the day's input comes from, for reporting
+	Name(day int) string
+}
+
+// An Opener is an InputSource that can hand over a day's input as a reader, so it can be read a bit at a time instead
+// of all at once.
+type Opener interface {
+	Open(day int) (io.ReadCloser, error)
+}
+
+// Open the day's input for reading. Sources that aren't Openers are loaded in full and read back from memory.
+func OpenInput(source InputSource, day int) (io.ReadCloser, error) {
+	if opener, ok := source.(Opener); ok {
+		return opener.Open(day)
+	}
+
+	input, err := source.Load(day)
+	if err != nil {
+		return nil, err
 	}
 
-	bytes, err := os.ReadFile(filepath.Dir(file) + "/input.txt")
+	return io.NopCloser(strings.NewReader(input)), nil
+}
+
+func expandDay(path string, day int) string {
+	return strings.ReplaceAll(path, DayPlaceholder, strconv.Itoa(day))
+}
+
+// Reads input from a path on disk, which may contain DayPlaceholder.
+type FileInput string
+
+func (f FileInput) Load(day int) (string, error) {
+	path := expandDay(string(f), day)
+
+	bytes, err := os.ReadFile(path)
 	if err != nil {
-		panic("Could not read local input file!")
+		return "", fmt.Errorf("could not read input file %s: %w", path, err)
 	}
 
-	return string(bytes)
+	return string(bytes), nil
+}
+
+func (f FileInput) Open(day int) (io.ReadCloser, error) {
+	path := expandDay(string(f), day)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open input file %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+func (f FileInput) Name(day int) string {
+	return expandDay(string(f), day)
+}
+
+// Reads the whole of a reader as the input, e.g. os.Stdin. The reader is consumed on the first load, so this only
+// makes sense for a single day.
+type ReaderInput struct {
+	Reader io.Reader
+	Label  string
+}
+
+func StdinInput() ReaderInput {
+	return ReaderInput{Reader: os.Stdin, Label: "stdin"}
+}
+
+func (r ReaderInput) Load(int) (string, error) {
+	bytes, err := io.ReadAll(r.Reader)
+	if err != nil {
+		return "", fmt.Errorf("could not read input from %s: %w", r.Label, err)
+	}
+
+	return string(bytes), nil
+}
+
+// Hand over the reader itself. Closing it is left to whoever created it.
+func (r ReaderInput) Open(int) (io.ReadCloser, error) {
+	return io.NopCloser(r.Reader), nil
+}
+
+func (r ReaderInput) Name(int) string {
+	return r.Label
+}
+
+// Reads input from a file system, typically an embed.FS, at a path which may contain DayPlaceholder.
+type FSInput struct {
+	FS   fs.FS
+	Path string
+}
+
+func (f FSInput) Load(day int) (string, error) {
+	path := expandDay(f.Path, day)
+
+	bytes, err := fs.ReadFile(f.FS, path)
+	if err != nil {
+		return "", fmt.Errorf("could not read embedded input %s: %w", path, err)
+	}
+
+	return string(bytes), nil
+}
+
+func (f FSInput) Open(day int) (io.ReadCloser, error) {
+	path := expandDay(f.Path, day)
+
+	file, err := f.FS.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open embedded input %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+func (f FSInput) Name(day int) string {
+	return "embedded:" + expandDay(f.Path, day)
+}
+
+// The conventional layout of dayN/input.txt beneath the given root directory
+func DayDirInput(root string) FileInput {
+	return FileInput(filepath.Join(root, "day"+DayPlaceholder, "input.txt"))
 }
@@ -0,0 +1,393 @@
+package support
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// An Interval is the integers from Start to End, including both ends. It's empty if Start is after End.
+type Interval struct {
+	Start int
+	End   int
+}
+
+func (i Interval) IsEmpty() bool {
+	return i.Start > i.End
+}
+
+func (i Interval) Contains(v int) bool {
+	return i.Start <= v && v <= i.End
+}
+
+// The number of integers in the interval.
+func (i Interval) Len() int {
+	if i.IsEmpty() {
+		return 0
+	}
+
+	return i.End - i.Start + 1
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("%d-%d", i.Start, i.End)
+}
+
+// Returns true if i ends before other starts with at least one integer between them, so they can't be merged.
+func (i Interval) before(other Interval) bool {
+	// other.Start can't be the smallest int if it's above i.End, so other.Start-1 is safe
+	return i.End < other.Start && i.End != other.Start-1
+}
+
+// An IntervalSet is a set of integers stored as non-overlapping intervals, with touching intervals merged so 1-3 and
+// 4-6 are held as 1-6. The intervals live in a treap ordered by start, a binary search tree kept balanced by giving
+// each node a random priority, so Add, Remove and Contains are all O(log n) in the number of intervals on average.
+// The zero value is an empty set ready to use.
+type IntervalSet struct {
+	root *intervalNode
+}
+
+type intervalNode struct {
+	interval    Interval
+	priority    uint64 // Parents always have a higher priority than their children
+	left, right *intervalNode
+	count       int // The number of intervals in this subtree
+	size        int // The number of integers covered by this subtree
+}
+
+func newIntervalNode(interval Interval) *intervalNode {
+	return &intervalNode{interval: interval, priority: rand.Uint64(), count: 1, size: interval.Len()}
+}
+
+// Recalculate n's totals from its children.
+func (n *intervalNode) update() {
+	n.count, n.size = 1, n.interval.Len()
+
+	for _, child := range []*intervalNode{n.left, n.right} {
+		if child != nil {
+			n.count += child.count
+			n.size += child.size
+		}
+	}
+}
+
+// Split the tree in two: the intervals goesLeft is true for, then the rest. goesLeft must be true for every interval
+// up to some point in order and false after it.
+func splitIntervals(n *intervalNode, goesLeft func(Interval) bool) (*intervalNode, *intervalNode) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if goesLeft(n.interval) {
+		left, right := splitIntervals(n.right, goesLeft)
+		n.right = left
+		n.update()
+
+		return n, right
+	}
+
+	left, right := splitIntervals(n.left, goesLeft)
+	n.left = right
+	n.update()
+
+	return left, n
+}
+
+// Join two trees where every interval in a comes before every interval in b.
+func joinIntervals(a, b *intervalNode) *intervalNode {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if a.priority > b.priority {
+		a.right = joinIntervals(a.right, b)
+		a.update()
+
+		return a
+	}
+
+	b.left = joinIntervals(a, b.left)
+	b.update()
+
+	return b
+}
+
+// The last interval in the tree, or false if the tree is empty.
+func lastInterval(n *intervalNode) (Interval, bool) {
+	if n == nil {
+		return Interval{}, false
+	}
+
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n.interval, true
+}
+
+// Remove the last interval from a non-empty tree, returning what's left.
+func withoutLastInterval(n *intervalNode) *intervalNode {
+	if n.right == nil {
+		return n.left
+	}
+
+	n.right = withoutLastInterval(n.right)
+	n.update()
+
+	return n
+}
+
+// Build a tree from intervals that are already sorted and merged.
+func intervalSetOf(merged []Interval) *IntervalSet {
+	s := &IntervalSet{}
+
+	for _, interval := range merged {
+		s.root = joinIntervals(s.root, newIntervalNode(interval))
+	}
+
+	return s
+}
+
+// Build a set covering every given interval, in any order and overlapping or not. This is O(n log n), so it's the
+// quickest way to build a set from a lot of intervals at once.
+func NewIntervalSet(intervals ...Interval) *IntervalSet {
+	return intervalSetOf(mergeIntervals(slices.Clone(intervals)))
+}
+
+// Sort and merge intervals in place, dropping empty ones.
+func mergeIntervals(intervals []Interval) []Interval {
+	intervals = slices.DeleteFunc(intervals, Interval.IsEmpty)
+	slices.SortFunc(intervals, func(a, b Interval) int { return cmp.Compare(a.Start, b.Start) })
+
+	merged := intervals[:0]
+
+	for _, interval := range intervals {
+		if len(merged) > 0 && !merged[len(merged)-1].before(interval) {
+			last := &merged[len(merged)-1]
+			last.End = max(last.End, interval.End)
+
+			continue
+		}
+
+		merged = append(merged, interval)
+	}
+
+	return merged
+}
+
+// Add every integer in interval to the set.
+func (s *IntervalSet) Add(interval Interval) {
+	if interval.IsEmpty() {
+		return
+	}
+
+	left, right := splitIntervals(s.root, func(i Interval) bool { return i.Start < interval.Start })
+
+	// The last interval starting before the new one may reach into it or touch it, in which case they merge
+	if last, ok := lastInterval(left); ok && !last.before(interval) {
+		left = withoutLastInterval(left)
+		interval = Interval{Start: last.Start, End: max(last.End, interval.End)}
+	}
+
+	// Everything else it overlaps or touches starts inside it, so those all merge into it too. They're in order, so
+	// the last of them reaches furthest.
+	swallowed, right := splitIntervals(right, func(i Interval) bool { return !interval.before(i) })
+	if last, ok := lastInterval(swallowed); ok {
+		interval.End = max(interval.End, last.End)
+	}
+
+	s.root = joinIntervals(joinIntervals(left, newIntervalNode(interval)), right)
+}
+
+// Remove every integer in interval from the set.
+func (s *IntervalSet) Remove(interval Interval) {
+	if interval.IsEmpty() {
+		return
+	}
+
+	left, right := splitIntervals(s.root, func(i Interval) bool { return i.Start < interval.Start })
+
+	// Whatever's left over past the end of the removed interval, if anything
+	var tail *intervalNode
+
+	// The last interval starting before the removed one may reach into it, in which case only its start survives,
+	// and its end if it reaches all the way through
+	if last, ok := lastInterval(left); ok && last.End >= interval.Start {
+		left = withoutLastInterval(left)
+		left = joinIntervals(left, newIntervalNode(Interval{Start: last.Start, End: interval.Start - 1}))
+
+		if last.End > interval.End {
+			tail = newIntervalNode(Interval{Start: interval.End + 1, End: last.End})
+		}
+	}
+
+	// Everything starting inside the removed interval goes, apart from any of the last one that reaches past it
+	removed, right := splitIntervals(right, func(i Interval) bool { return i.Start <= interval.End })
+	if last, ok := lastInterval(removed); ok && last.End > interval.End {
+		tail = newIntervalNode(Interval{Start: interval.End + 1, End: last.End})
+	}
+
+	s.root = joinIntervals(joinIntervals(left, tail), right)
+}
+
+func (s *IntervalSet) Contains(v int) bool {
+	for n := s.root; n != nil; {
+		switch {
+		case v < n.interval.Start:
+			n = n.left
+		case v > n.interval.End:
+			n = n.right
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+// The number of separate intervals in the set.
+func (s *IntervalSet) Len() int {
+	if s.root == nil {
+		return 0
+	}
+
+	return s.root.count
+}
+
+// The number of integers in the set.
+func (s *IntervalSet) Size() int {
+	if s.root == nil {
+		return 0
+	}
+
+	return s.root.size
+}
+
+// The smallest interval covering the whole set, or false if the set is empty.
+func (s *IntervalSet) Bounds() (Interval, bool) {
+	if s.root == nil {
+		return Interval{}, false
+	}
+
+	first, last := s.root, s.root
+	for first.left != nil {
+		first = first.left
+	}
+
+	for last.right != nil {
+		last = last.right
+	}
+
+	return Interval{Start: first.interval.Start, End: last.interval.End}, true
+}
+
+// Iterate over the intervals in ascending order.
+func (s *IntervalSet) All() iter.Seq[Interval] {
+	return func(yield func(Interval) bool) {
+		var walk func(n *intervalNode) bool
+		walk = func(n *intervalNode) bool {
+			return n == nil || (walk(n.left) && yield(n.interval) && walk(n.right))
+		}
+
+		walk(s.root)
+	}
+}
+
+// The intervals in ascending order, for the set operations that walk them in step.
+func (s *IntervalSet) intervals() []Interval {
+	return slices.AppendSeq(make([]Interval, 0, s.Len()), s.All())
+}
+
+func (s *IntervalSet) Clone() *IntervalSet {
+	return intervalSetOf(s.intervals())
+}
+
+func (s *IntervalSet) Equal(other *IntervalSet) bool {
+	return s.Len() == other.Len() && slices.Equal(s.intervals(), other.intervals())
+}
+
+// Return a new set of the integers in either set.
+func (s *IntervalSet) Union(other *IntervalSet) *IntervalSet {
+	return intervalSetOf(mergeIntervals(slices.Concat(s.intervals(), other.intervals())))
+}
+
+// Return a new set of the integers in both sets.
+func (s *IntervalSet) Intersect(other *IntervalSet) *IntervalSet {
+	ours, theirs := s.intervals(), other.intervals()
+	intersection := make([]Interval, 0)
+
+	// Walk both lists together, always moving on from whichever interval ends first as nothing later can overlap it
+	for i, j := 0, 0; i < len(ours) && j < len(theirs); {
+		a, b := ours[i], theirs[j]
+
+		if overlap := (Interval{Start: max(a.Start, b.Start), End: min(a.End, b.End)}); !overlap.IsEmpty() {
+			intersection = append(intersection, overlap)
+		}
+
+		if a.End < b.End {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return intervalSetOf(intersection)
+}
+
+// Return a new set of the integers in s that aren't in other.
+func (s *IntervalSet) Subtract(other *IntervalSet) *IntervalSet {
+	bounds, ok := s.Bounds()
+	if !ok {
+		return &IntervalSet{}
+	}
+
+	return s.Intersect(other.Complement(bounds))
+}
+
+// Return a new set of the integers within bounds that aren't in s.
+func (s *IntervalSet) Complement(bounds Interval) *IntervalSet {
+	if bounds.IsEmpty() {
+		return &IntervalSet{}
+	}
+
+	complement := make([]Interval, 0)
+	next := bounds.Start
+
+	for interval := range s.All() {
+		if interval.End < next {
+			continue
+		}
+
+		if interval.Start > bounds.End {
+			break
+		}
+
+		if interval.Start > next {
+			complement = append(complement, Interval{Start: next, End: interval.Start - 1})
+		}
+
+		// Nothing is left to fill once an interval reaches the end of bounds, and stopping here means End+1 can't
+		// overflow
+		if interval.End >= bounds.End {
+			return intervalSetOf(complement)
+		}
+
+		next = interval.End + 1
+	}
+
+	complement = append(complement, Interval{Start: next, End: bounds.End})
+
+	return intervalSetOf(complement)
+}
+
+// Render the set as comma separated intervals, e.g. "1-3, 7-9".
+func (s *IntervalSet) String() string {
+	return strings.Join(Map(s.intervals(), Interval.String), ", ")
+}
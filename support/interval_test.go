@@ -0,0 +1,241 @@
+package support
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// How far the model tests reach from their base, small enough to track every integer in a map
+const modelWidth = 40
+
+// A set of integers base+offset, kept as the offsets, to check IntervalSet against.
+type modelSet map[int]bool
+
+func (m modelSet) intervals(base int) []Interval {
+	offsets := make([]int, 0, len(m))
+	for offset := range m {
+		offsets = append(offsets, offset)
+	}
+
+	slices.Sort(offsets)
+
+	intervals := make([]Interval, 0)
+
+	for _, offset := range offsets {
+		if n := len(intervals); n > 0 && intervals[n-1].End == base+offset-1 {
+			intervals[n-1].End++
+			continue
+		}
+
+		intervals = append(intervals, Interval{Start: base + offset, End: base + offset})
+	}
+
+	return intervals
+}
+
+// A random interval within the model's reach, sometimes empty and sometimes running off either end.
+func randomInterval(rng *rand.Rand, base int) (Interval, int, int) {
+	start := rng.IntN(modelWidth + 1)
+	end := min(start+rng.IntN(12)-2, modelWidth)
+
+	// Empty intervals end just before they start, which mustn't fall below the smallest int
+	if end < start {
+		start = max(start, 1)
+		end = start - 1
+	}
+
+	return Interval{Start: base + start, End: base + end}, start, end
+}
+
+func randomSet(rng *rand.Rand, base int) (*IntervalSet, modelSet) {
+	s, m := &IntervalSet{}, modelSet{}
+
+	for range rng.IntN(6) {
+		interval, start, end := randomInterval(rng, base)
+		s.Add(interval)
+
+		for offset := start; offset <= end; offset++ {
+			m[offset] = true
+		}
+	}
+
+	return s, m
+}
+
+func checkAgainstModel(t *testing.T, s *IntervalSet, m modelSet, base int, what string) {
+	t.Helper()
+
+	want := m.intervals(base)
+	if got := slices.Collect(s.All()); !slices.Equal(got, want) {
+		t.Fatalf("%s: got %v, want %v", what, got, want)
+	}
+
+	if s.Len() != len(want) {
+		t.Fatalf("%s: Len() = %d, want %d", what, s.Len(), len(want))
+	}
+
+	if s.Size() != len(m) {
+		t.Fatalf("%s: Size() = %d, want %d", what, s.Size(), len(m))
+	}
+
+	for offset := range modelWidth + 1 {
+		if s.Contains(base+offset) != m[offset] {
+			t.Fatalf("%s: Contains(%d) = %v, want %v", what, base+offset, !m[offset], m[offset])
+		}
+	}
+
+	bounds, ok := s.Bounds()
+	if ok != (len(want) > 0) || ok && bounds != (Interval{Start: want[0].Start, End: want[len(want)-1].End}) {
+		t.Fatalf("%s: Bounds() = %v, %v for %v", what, bounds, ok, want)
+	}
+}
+
+func TestIntervalSetMatchesModel(t *testing.T) {
+	rng := rand.New(rand.NewPCG(21, 2025))
+
+	for _, base := range []int{0, math.MinInt, math.MaxInt - modelWidth} {
+		for range 500 {
+			s, m := &IntervalSet{}, modelSet{}
+
+			for range 40 {
+				interval, start, end := randomInterval(rng, base)
+
+				if rng.IntN(3) == 0 {
+					s.Remove(interval)
+
+					for offset := start; offset <= end; offset++ {
+						delete(m, offset)
+					}
+
+					checkAgainstModel(t, s, m, base, "after removing "+interval.String())
+				} else {
+					s.Add(interval)
+
+					for offset := start; offset <= end; offset++ {
+						m[offset] = true
+					}
+
+					checkAgainstModel(t, s, m, base, "after adding "+interval.String())
+				}
+			}
+		}
+	}
+}
+
+func TestIntervalSetOperationsMatchModel(t *testing.T) {
+	rng := rand.New(rand.NewPCG(2025, 21))
+
+	for _, base := range []int{0, math.MinInt, math.MaxInt - modelWidth} {
+		for range 2000 {
+			a, am := randomSet(rng, base)
+			b, bm := randomSet(rng, base)
+
+			union, intersection, difference := modelSet{}, modelSet{}, modelSet{}
+
+			for offset := range am {
+				union[offset] = true
+
+				if bm[offset] {
+					intersection[offset] = true
+				} else {
+					difference[offset] = true
+				}
+			}
+
+			for offset := range bm {
+				union[offset] = true
+			}
+
+			checkAgainstModel(t, a.Union(b), union, base, a.String()+" union "+b.String())
+			checkAgainstModel(t, a.Intersect(b), intersection, base, a.String()+" intersect "+b.String())
+			checkAgainstModel(t, a.Subtract(b), difference, base, a.String()+" subtract "+b.String())
+
+			bounds, start, end := randomInterval(rng, base)
+			complement := modelSet{}
+
+			for offset := start; offset <= end; offset++ {
+				if !am[offset] {
+					complement[offset] = true
+				}
+			}
+
+			checkAgainstModel(t, a.Complement(bounds), complement, base, a.String()+" complement in "+bounds.String())
+
+			if !a.Clone().Equal(a) || a.Equal(b) != slices.Equal(am.intervals(base), bm.intervals(base)) {
+				t.Fatalf("Clone or Equal disagree for %v and %v", a, b)
+			}
+		}
+	}
+}
+
+func TestIntervalSetEdgeCases(t *testing.T) {
+	build := func(intervals ...Interval) *IntervalSet {
+		s := &IntervalSet{}
+		for _, interval := range intervals {
+			s.Add(interval)
+		}
+
+		return s
+	}
+
+	tests := []struct {
+		name string
+		set  *IntervalSet
+		want string
+	}{
+		{"touching intervals merge", build(Interval{1, 3}, Interval{4, 6}), "1-6"},
+		{"touching in reverse merge", build(Interval{4, 6}, Interval{1, 3}), "1-6"},
+		{"a gap of one stays apart", build(Interval{1, 3}, Interval{5, 6}), "1-3, 5-6"},
+		{"filling a gap joins both sides", build(Interval{1, 3}, Interval{5, 6}, Interval{4, 4}), "1-6"},
+		{"nested inside", build(Interval{1, 10}, Interval{3, 4}), "1-10"},
+		{"swallowing several", build(Interval{2, 3}, Interval{5, 6}, Interval{8, 9}, Interval{1, 10}), "1-10"},
+		{"empty intervals are ignored", build(Interval{5, 4}), ""},
+		{"removing the middle splits", func() *IntervalSet {
+			s := build(Interval{1, 10})
+			s.Remove(Interval{4, 6})
+			return s
+		}(), "1-3, 7-10"},
+		{"removing across several", func() *IntervalSet {
+			s := build(Interval{1, 3}, Interval{5, 7}, Interval{9, 12})
+			s.Remove(Interval{2, 10})
+			return s
+		}(), "1-1, 11-12"},
+		{"touching at the smallest int", build(Interval{math.MinInt, math.MinInt}, Interval{math.MinInt + 1, 0}),
+			Interval{math.MinInt, 0}.String()},
+		{"touching at the largest int", build(Interval{math.MaxInt, math.MaxInt}, Interval{0, math.MaxInt - 1}),
+			Interval{0, math.MaxInt}.String()},
+		{"removing the largest int", func() *IntervalSet {
+			s := build(Interval{0, math.MaxInt})
+			s.Remove(Interval{math.MaxInt, math.MaxInt})
+			return s
+		}(), Interval{0, math.MaxInt - 1}.String()},
+		{"removing the smallest int", func() *IntervalSet {
+			s := build(Interval{math.MinInt, 0})
+			s.Remove(Interval{math.MinInt, math.MinInt})
+			return s
+		}(), Interval{math.MinInt + 1, 0}.String()},
+		{"everything", build(Interval{math.MinInt, -1}, Interval{0, math.MaxInt}),
+			Interval{math.MinInt, math.MaxInt}.String()},
+		{"complement reaching the largest int", build(Interval{0, 5}).Complement(Interval{0, math.MaxInt}),
+			Interval{6, math.MaxInt}.String()},
+		{"complement of everything", build(Interval{math.MinInt, math.MaxInt}).Complement(
+			Interval{math.MinInt, math.MaxInt}), ""},
+		{"complement of nothing", (&IntervalSet{}).Complement(Interval{math.MinInt, math.MaxInt}),
+			Interval{math.MinInt, math.MaxInt}.String()},
+	}
+
+	for _, test := range tests {
+		if got := test.set.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	full := build(Interval{math.MinInt, math.MaxInt})
+	for _, v := range []int{math.MinInt, -1, 0, math.MaxInt} {
+		if !full.Contains(v) {
+			t.Errorf("set of every int doesn't contain %d", v)
+		}
+	}
+}
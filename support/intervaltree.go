@@ -0,0 +1,113 @@
+package support
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// A LabelledInterval is an interval along with something saying where it came from, e.g. its line in the input.
+type LabelledInterval[L any] struct {
+	Interval
+	Label L
+}
+
+// An IntervalTree holds intervals as they were given, without merging them, so it can report every one that overlaps a
+// value. It's the ranges sorted by start, treated as a balanced binary tree where each node records the largest end
+// beneath it, so whole subtrees that end too early are skipped. A query is O(log n + k) for k matches.
+//
+// The tree is built once by NewIntervalTree and can't be changed afterwards.
+type IntervalTree[L any] struct {
+	entries []LabelledInterval[L] // Sorted by start; the node for entries[lo:hi] is the middle one
+	maxEnd  []int                 // maxEnd[i] is the largest end in the subtree whose node is entries[i]
+}
+
+// Build a tree of the given intervals. Empty intervals can never overlap anything, so they're left out.
+func NewIntervalTree[L any](intervals ...LabelledInterval[L]) *IntervalTree[L] {
+	entries := slices.DeleteFunc(slices.Clone(intervals), func(e LabelledInterval[L]) bool { return e.IsEmpty() })
+
+	// Stable, so intervals with the same start are reported in the order they were given
+	slices.SortStableFunc(entries, func(a, b LabelledInterval[L]) int { return cmp.Compare(a.Start, b.Start) })
+
+	t := &IntervalTree[L]{entries: entries, maxEnd: make([]int, len(entries))}
+	if len(entries) > 0 {
+		t.build(0, len(entries))
+	}
+
+	return t
+}
+
+// Fill in maxEnd for the subtree over entries[lo:hi], returning its largest end.
+func (t *IntervalTree[L]) build(lo, hi int) int {
+	mid := (lo + hi) / 2
+	t.maxEnd[mid] = t.entries[mid].End
+
+	if lo < mid {
+		t.maxEnd[mid] = max(t.maxEnd[mid], t.build(lo, mid))
+	}
+
+	if mid+1 < hi {
+		t.maxEnd[mid] = max(t.maxEnd[mid], t.build(mid+1, hi))
+	}
+
+	return t.maxEnd[mid]
+}
+
+func (t *IntervalTree[L]) Len() int {
+	return len(t.entries)
+}
+
+// Iterate over every interval in the tree, ordered by start.
+func (t *IntervalTree[L]) All() iter.Seq[LabelledInterval[L]] {
+	return slices.Values(t.entries)
+}
+
+// Iterate over the intervals that include v, ordered by start.
+func (t *IntervalTree[L]) Covering(v int) iter.Seq[LabelledInterval[L]] {
+	return t.Overlapping(Interval{Start: v, End: v})
+}
+
+// Iterate over the intervals that share at least one integer with query, ordered by start.
+func (t *IntervalTree[L]) Overlapping(query Interval) iter.Seq[LabelledInterval[L]] {
+	return func(yield func(LabelledInterval[L]) bool) {
+		if !query.IsEmpty() {
+			t.overlapping(query, 0, len(t.entries), yield)
+		}
+	}
+}
+
+// Yield the overlapping intervals in the subtree over entries[lo:hi] in order, returning false once yield has.
+func (t *IntervalTree[L]) overlapping(query Interval, lo, hi int, yield func(LabelledInterval[L]) bool) bool {
+	if lo >= hi {
+		return true
+	}
+
+	mid := (lo + hi) / 2
+
+	// Nothing here reaches the query
+	if t.maxEnd[mid] < query.Start {
+		return true
+	}
+
+	if !t.overlapping(query, lo, mid, yield) {
+		return false
+	}
+
+	// Everything from here on starts after the query has finished
+	if t.entries[mid].Start > query.End {
+		return true
+	}
+
+	if t.entries[mid].End >= query.Start && !yield(t.entries[mid]) {
+		return false
+	}
+
+	return t.overlapping(query, mid+1, hi, yield)
+}
+
+// The integers covered by at least one interval in the tree, with the overlaps merged away.
+func (t *IntervalTree[L]) Merged() *IntervalSet {
+	intervals := Map(t.entries, func(e LabelledInterval[L]) Interval { return e.Interval })
+
+	return intervalSetOf(mergeIntervals(intervals))
+}
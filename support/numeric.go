@@ -1,25 +1,35 @@
 package support
 
 import (
+	"errors"
 	"fmt"
 	"iter"
-	"math"
+	"math/bits"
 	"strconv"
 	"strings"
 )
 
-func AbsInt[T int | int64](i T) int {
+// Any built-in integer type, or a type based on one.
+type Integer interface {
+	Signed | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+func AbsInt[T Signed](i T) T {
 	if i < 0 {
-		return int(-i)
+		return -i
 	}
 
-	return int(i)
+	return i
 }
 
-func MinInt(ints ...int) int {
-	min := math.MaxInt
+func MinInt[T Integer](first T, rest ...T) T {
+	min := first
 
-	for _, i := range ints {
+	for _, i := range rest {
 		if i < min {
 			min = i
 		}
@@ -28,10 +38,10 @@ func MinInt(ints ...int) int {
 	return min
 }
 
-func MaxInt(ints ...int) int {
-	max := math.MinInt
+func MaxInt[T Integer](first T, rest ...T) T {
+	max := first
 
-	for _, i := range ints {
+	for _, i := range rest {
 		if i > max {
 			max = i
 		}
@@ -40,35 +50,35 @@ func MaxInt(ints ...int) int {
 	return max
 }
 
-func StringOfDigitsAsSliceOfInts(in string) []int {
+func StringOfDigitsAsSliceOfInts(in string) ([]int, error) {
 	digits := strings.Split(in, "")
 	out := make([]int, 0, len(digits))
 
-	for _, digit := range digits {
+	for i, digit := range digits {
 		intDigit, err := strconv.Atoi(digit)
 		if err != nil {
-			panic(fmt.Sprintf("Could not convert %s to int", digit))
+			return nil, fmt.Errorf("could not convert %q at position %d to int", digit, i+1)
 		}
 
 		out = append(out, intDigit)
 	}
 
-	return out
+	return out, nil
 }
 
-func SliceOfNumericStringsToSliceOfInts(in []string) []int {
+func SliceOfNumericStringsToSliceOfInts(in []string) ([]int, error) {
 	out := make([]int, len(in))
 
 	for i, numericStr := range in {
 		asInt, err := strconv.Atoi(numericStr)
 		if err != nil {
-			panic(fmt.Sprintf("Could not convert %s to int", numericStr))
+			return nil, fmt.Errorf("could not convert %q to int", numericStr)
 		}
 
 		out[i] = asInt
 	}
 
-	return out
+	return out, nil
 }
 
 func SumSeq(s iter.Seq[int]) int {
@@ -81,13 +91,209 @@ func SumSeq(s iter.Seq[int]) int {
 	return total
 }
 
-func Gcd(a, b int) int {
+func Gcd[T Integer](a, b T) T {
 	for b != 0 {
 		a, b = b, a%b
 	}
+
+	if a < 0 {
+		return -a
+	}
+
+	return a
+}
+
+// The lowest common multiple of a and b. Divides before multiplying, so it only overflows if the result itself doesn't
+// fit in a T.
+func Lcm[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	lcm, err := MulChecked(a/Gcd(a, b), b)
+	if err != nil {
+		return 0, fmt.Errorf("lcm of %d and %d: %w", a, b, err)
+	}
+
+	if lcm < 0 {
+		return -lcm, nil
+	}
+
+	return lcm, nil
+}
+
+var ErrOverflow = errors.New("integer overflow")
+
+func AddChecked[T Integer](a, b T) (T, error) {
+	sum := a + b
+
+	// Overflow wraps around, so the sum ends up on the wrong side of a
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, fmt.Errorf("%d + %d: %w", a, b, ErrOverflow)
+	}
+
+	return sum, nil
+}
+
+func SubChecked[T Integer](a, b T) (T, error) {
+	diff := a - b
+
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return 0, fmt.Errorf("%d - %d: %w", a, b, ErrOverflow)
+	}
+
+	return diff, nil
+}
+
+func MulChecked[T Integer](a, b T) (T, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	product := a * b
+
+	// Dividing back out catches most overflows. The exception is the most negative value times -1, which wraps back
+	// round to itself; -a < 0 only holds for that value.
+	if product/b != a || (a < 0 && -a < 0 && b < 0) || (b < 0 && -b < 0 && a < 0) {
+		return 0, fmt.Errorf("%d * %d: %w", a, b, ErrOverflow)
+	}
+
+	return product, nil
+}
+
+// Raise base to a non-negative power by repeated squaring.
+func Pow[T Integer](base T, exp int) (T, error) {
+	if exp < 0 {
+		return 0, fmt.Errorf("%d^%d: negative exponent", base, exp)
+	}
+
+	result, square := T(1), base
+
+	for remaining := exp; remaining > 0; remaining >>= 1 {
+		var err error
+
+		if remaining&1 == 1 {
+			if result, err = MulChecked(result, square); err != nil {
+				return 0, fmt.Errorf("%d^%d: %w", base, exp, ErrOverflow)
+			}
+		}
+
+		// Don't square one more time than needed, as it might overflow when the result wouldn't
+		if remaining > 1 {
+			if square, err = MulChecked(square, square); err != nil {
+				return 0, fmt.Errorf("%d^%d: %w", base, exp, ErrOverflow)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Return base^exp mod m, in the range [0, m). Intermediate products are done in 128 bits, so this can't overflow.
+func ModPow[T Integer](base T, exp int, mod T) (T, error) {
+	if mod <= 0 {
+		return 0, fmt.Errorf("modulus %d must be positive", mod)
+	}
+
+	if exp < 0 {
+		return 0, fmt.Errorf("%d^%d: negative exponent", base, exp)
+	}
+
+	result := 1 % mod
+	base = normaliseMod(base, mod)
+
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, mod)
+		}
+
+		base = mulMod(base, base, mod)
+	}
+
+	return result, nil
+}
+
+// Reduce a into [0, m) for a positive m, unlike % which keeps the sign of a.
+func normaliseMod[T Integer](a, m T) T {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+
 	return a
 }
 
-func Lcm(a, b int) int {
-	return (a * b) / Gcd(a, b)
+// Multiply a and b modulo m, where both are already in [0, m).
+func mulMod[T Integer](a, b, m T) T {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+
+	return T(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// Return the gcd of a and b along with x and y such that ax + by = gcd(a, b).
+func ExtendedGcd[T Signed](a, b T) (gcd, x, y T) {
+	oldR, r := a, b
+	oldX, x := T(1), T(0)
+	oldY, y := T(0), T(1)
+
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+
+	return oldR, oldX, oldY
+}
+
+// Solve the system x ≡ residues[i] (mod moduli[i]) with the Chinese Remainder Theorem, returning the smallest
+// non-negative x and the modulus it repeats with. Moduli don't need to be coprime, but then there may be no solution.
+func CRT[T Signed](residues, moduli []T) (T, T, error) {
+	if len(residues) != len(moduli) {
+		return 0, 0, fmt.Errorf("got %d residues but %d moduli", len(residues), len(moduli))
+	}
+
+	x, m := T(0), T(1)
+
+	for i, mi := range moduli {
+		if mi <= 0 {
+			return 0, 0, fmt.Errorf("modulus %d must be positive", mi)
+		}
+
+		ri := normaliseMod(residues[i], mi)
+
+		// Combine x (mod m) with ri (mod mi): we need t with x + m*t ≡ ri (mod mi), which only exists if the
+		// difference is a multiple of gcd(m, mi)
+		g, p, _ := ExtendedGcd(m, mi)
+
+		diff, err := SubChecked(ri, x)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if diff%g != 0 {
+			return 0, 0, fmt.Errorf("x ≡ %d (mod %d) contradicts the earlier congruences", residues[i], mi)
+		}
+
+		step := mi / g
+		t := mulMod(normaliseMod(diff/g, step), normaliseMod(p, step), step)
+
+		lcm, err := MulChecked(m/g, mi)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// m*t < lcm, as t < mi/g
+		if x, err = AddChecked(x, mulMod(m, t, lcm)); err != nil {
+			return 0, 0, err
+		}
+
+		x, m = normaliseMod(x, lcm), lcm
+	}
+
+	return x, m, nil
 }
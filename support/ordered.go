@@ -0,0 +1,131 @@
+package support
+
+import (
+	"iter"
+	"slices"
+)
+
+// An OrderedMap is a map that remembers the order keys were first added in, so iterating over it gives the same result
+// every run. Deleting is O(n), which is fine for the sizes of map puzzles need.
+type OrderedMap[K comparable, V any] struct {
+	keys   []K
+	values map[K]V
+}
+
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{values: make(map[K]V)}
+}
+
+// Set key to value. A new key goes to the end of the order; an existing one keeps its place.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+
+	m.values[key] = value
+}
+
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	value, ok := m.values[key]
+
+	return value, ok
+}
+
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.values[key]
+
+	return ok
+}
+
+// Delete key, returning whether it was there to delete.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	if _, ok := m.values[key]; !ok {
+		return false
+	}
+
+	delete(m.values, key)
+
+	i := slices.Index(m.keys, key)
+	m.keys = slices.Delete(m.keys, i, i+1)
+
+	return true
+}
+
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Iterate over the entries in the order they were added. Changing the map mid-iteration is only safe if the loop stops
+// straight afterwards.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := 0; i < len(m.keys); i++ {
+			if !yield(m.keys[i], m.values[m.keys[i]]) {
+				return
+			}
+		}
+	}
+}
+
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// An OrderedSet is a Set that remembers the order items were first added in.
+type OrderedSet[T comparable] struct {
+	items *OrderedMap[T, struct{}]
+}
+
+func NewOrderedSet[T comparable](initial ...T) *OrderedSet[T] {
+	s := &OrderedSet[T]{items: NewOrderedMap[T, struct{}]()}
+
+	for _, v := range initial {
+		s.Add(v)
+	}
+
+	return s
+}
+
+func (s *OrderedSet[T]) Add(item T) bool {
+	if s.items.Has(item) {
+		return false
+	}
+
+	s.items.Set(item, struct{}{})
+
+	return true
+}
+
+func (s *OrderedSet[T]) Has(item T) bool {
+	return s.items.Has(item)
+}
+
+// Remove item from the set, returning whether it was there to remove.
+func (s *OrderedSet[T]) Remove(item T) bool {
+	return s.items.Delete(item)
+}
+
+func (s *OrderedSet[T]) Len() int {
+	return s.items.Len()
+}
+
+// Iterate over the items in the order they were added.
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return s.items.Keys()
+}
@@ -0,0 +1,210 @@
+package support
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A ParseError describes a problem with a specific line of puzzle input.
+type ParseError struct {
+	Line   int    // 1-based line number within the input, or 0 if not yet known
+	Column int    // 1-based column within Text, or 0 if the problem is with the text as a whole
+	Text   string // The offending text
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	position := make([]string, 0, 2)
+
+	if e.Line > 0 {
+		position = append(position, fmt.Sprintf("line %d", e.Line))
+	}
+
+	if e.Column > 0 {
+		position = append(position, fmt.Sprintf("column %d", e.Column))
+	}
+
+	if len(position) == 0 {
+		return fmt.Sprintf("%q: %v", e.Text, e.Err)
+	}
+
+	return fmt.Sprintf("%s %q: %v", strings.Join(position, " "), e.Text, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Convert Windows and old Mac line endings to \n, and drop any blank lines at the end of the input. Whitespace on the
+// last line itself is kept, as some puzzles lay out columns with spaces.
+func Normalise(input string) string {
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	input = strings.ReplaceAll(input, "\r", "\n")
+
+	lines := strings.Split(input, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Split normalised input into lines, trimming trailing whitespace from each. An input ending in a newline doesn't
+// produce an empty last line.
+func Lines(input string) []string {
+	lines := RawLines(input)
+
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+
+	return lines
+}
+
+// Split normalised input into lines exactly as they appear, for puzzles where whitespace is significant.
+func RawLines(input string) []string {
+	input = Normalise(input)
+	if input == "" {
+		return []string{}
+	}
+
+	return strings.Split(input, "\n")
+}
+
+// A Section is a run of lines separated from the rest of the input by blank lines.
+type Section struct {
+	Start int // 1-based line number of the first line within the whole input
+	Lines []string
+}
+
+// Split normalised input into sections wherever there are one or more blank lines.
+func Sections(input string) []Section {
+	sections := make([]Section, 0)
+	var current *Section
+
+	for i, line := range Lines(input) {
+		if line == "" {
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			sections = append(sections, Section{Start: i + 1})
+			current = &sections[len(sections)-1]
+		}
+
+		current.Lines = append(current.Lines, line)
+	}
+
+	return sections
+}
+
+// Split a line on sep, trimming whitespace around each field. An empty sep splits on runs of whitespace.
+func Fields(line, sep string) []string {
+	if sep == "" {
+		return strings.Fields(line)
+	}
+
+	fields := strings.Split(line, sep)
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+	}
+
+	return fields
+}
+
+// Split a line on sep and convert every field to an int. Errors point at the column of the offending field.
+func IntFields(line, sep string) ([]int, error) {
+	ints := make([]int, 0)
+	column := 1
+
+	for _, field := range strings.Split(line, sep) {
+		trimmed := strings.TrimSpace(field)
+
+		value, err := strconv.Atoi(trimmed)
+		if err != nil {
+			return nil, &ParseError{
+				Column: column + strings.Index(field, trimmed),
+				Text:   line,
+				Err:    fmt.Errorf("%q is not an integer", trimmed),
+			}
+		}
+
+		ints = append(ints, value)
+		column += len(field) + len(sep)
+	}
+
+	return ints, nil
+}
+
+// Extract every integer from a line, ignoring whatever surrounds them. A - is treated as a minus sign only if it isn't
+// immediately preceded by a digit, so ranges like "3-5" give 3 and 5 rather than 3 and -5.
+func Ints(line string) ([]int, error) {
+	ints := make([]int, 0)
+
+	for i := 0; i < len(line); {
+		start := i
+		if line[i] == '-' && (i == 0 || !isDigit(line[i-1])) && i+1 < len(line) && isDigit(line[i+1]) {
+			i++
+		}
+
+		if !isDigit(line[i]) {
+			i++
+			continue
+		}
+
+		for i < len(line) && isDigit(line[i]) {
+			i++
+		}
+
+		value, err := strconv.Atoi(line[start:i])
+		if err != nil {
+			return nil, &ParseError{Column: start + 1, Text: line, Err: errors.Unwrap(err)}
+		}
+
+		ints = append(ints, value)
+	}
+
+	return ints, nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// Apply fn to each line, stopping at the first error and returning it as a ParseError for that line.
+func ParseLines[O any](lines []string, fn func(string) (O, error)) ([]O, error) {
+	return ParseSection(Section{Start: 1, Lines: lines}, fn)
+}
+
+// Apply fn to each line of a section, stopping at the first error and returning it as a ParseError with the line's
+// number in the whole input.
+func ParseSection[O any](section Section, fn func(string) (O, error)) ([]O, error) {
+	output := make([]O, 0, len(section.Lines))
+
+	for i, line := range section.Lines {
+		parsed, err := fn(line)
+		if err != nil {
+			return nil, LineError(section.Start+i, line, err)
+		}
+
+		output = append(output, parsed)
+	}
+
+	return output, nil
+}
+
+// Attach a line number to err. If err is already a ParseError that just didn't know which line it was on, that is
+// filled in rather than wrapped, so the column is kept.
+func LineError(lineNo int, line string, err error) error {
+	if parseErr, ok := err.(*ParseError); ok && parseErr.Line == 0 {
+		parseErr.Line = lineNo
+
+		return parseErr
+	}
+
+	return &ParseError{Line: lineNo, Text: line, Err: err}
+}
@@ -1,5 +1,12 @@
 package support
 
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
 type Set[T comparable] map[T]struct{}
 
 func (s Set[T]) Add(item T) bool {
@@ -18,6 +25,109 @@ func (s Set[T]) Has(item T) bool {
 	return ok
 }
 
+// Remove item from the set, returning whether it was there to remove.
+func (s Set[T]) Remove(item T) bool {
+	if !s.Has(item) {
+		return false
+	}
+
+	delete(s, item)
+
+	return true
+}
+
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+func (s Set[T]) Clone() Set[T] {
+	return maps.Clone(s)
+}
+
+// Iterate over the set's items in no particular order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// Return the set's items ordered by cmp, for when iteration order affects the result.
+func (s Set[T]) SortedFunc(cmp func(a, b T) int) []T {
+	return slices.SortedFunc(s.All(), cmp)
+}
+
+// Return the items of a set of ordered values in ascending order.
+func SortedItems[T cmp.Ordered](s Set[T]) []T {
+	return slices.Sorted(s.All())
+}
+
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// Returns true if every item in s is also in other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+
+	for item := range s {
+		if !other.Has(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Return a new set of the items in either set.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	// Not s.Clone(), which would give back a nil map to add to if s is nil
+	union := make(Set[T], len(s)+len(other))
+	maps.Copy(union, s)
+	maps.Copy(union, other)
+
+	return union
+}
+
+// Return a new set of the items in both sets.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	// Walk the smaller set, as every item in the intersection must be in it
+	smaller, larger := s, other
+	if len(larger) < len(smaller) {
+		smaller, larger = larger, smaller
+	}
+
+	intersection := make(Set[T])
+
+	for item := range smaller {
+		if larger.Has(item) {
+			intersection.Add(item)
+		}
+	}
+
+	return intersection
+}
+
+// Return a new set of the items in s that aren't in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	difference := make(Set[T])
+
+	for item := range s {
+		if !other.Has(item) {
+			difference.Add(item)
+		}
+	}
+
+	return difference
+}
+
+// Return a new set of the items in exactly one of the two sets.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	difference := s.Difference(other)
+	maps.Copy(difference, other.Difference(s))
+
+	return difference
+}
+
 func NewSet[T comparable](initial ...T) Set[T] {
 	s := make(Set[T], len(initial))
 
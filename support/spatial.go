@@ -1,30 +1,175 @@
 package support
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 type Point2 struct {
 	X int
 	Y int
 }
 
-// The 8 relative positions around a cell in a 2D grid
-var RelativeCardinalDirections = []Point2{
-	{X: -1, Y: 0},
-	{X: -1, Y: -1},
-	{X: 0, Y: -1},
-	{X: 1, Y: -1},
-	{X: 1, Y: 0},
-	{X: 1, Y: 1},
-	{X: 0, Y: 1},
-	{X: -1, Y: 1},
+func (p Point2) Add(other Point2) Point2 {
+	return Point2{X: p.X + other.X, Y: p.Y + other.Y}
+}
+
+func (p Point2) Sub(other Point2) Point2 {
+	return Point2{X: p.X - other.X, Y: p.Y - other.Y}
+}
+
+func (p Point2) Scale(k int) Point2 {
+	return Point2{X: p.X * k, Y: p.Y * k}
+}
+
+func (p Point2) Neg() Point2 {
+	return Point2{X: -p.X, Y: -p.Y}
+}
+
+// The number of orthogonal steps between two points.
+func (p Point2) Manhattan(other Point2) int {
+	return AbsInt(p.X-other.X) + AbsInt(p.Y-other.Y)
+}
+
+// The number of steps between two points when diagonal steps are allowed.
+func (p Point2) Chebyshev(other Point2) int {
+	return max(AbsInt(p.X-other.X), AbsInt(p.Y-other.Y))
+}
+
+// Rotate 90 degrees clockwise about the origin. Y grows downwards, as it does in a grid, so north turns to east.
+func (p Point2) RotateClockwise() Point2 {
+	return Point2{X: -p.Y, Y: p.X}
+}
+
+// Rotate 90 degrees anticlockwise about the origin, turning north to west.
+func (p Point2) RotateAnticlockwise() Point2 {
+	return Point2{X: p.Y, Y: -p.X}
+}
+
+// A compass direction on a grid where Y grows downwards, numbered clockwise from north.
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+var directionOffsets = [...]Point2{
+	North:     {X: 0, Y: -1},
+	NorthEast: {X: 1, Y: -1},
+	East:      {X: 1, Y: 0},
+	SouthEast: {X: 1, Y: 1},
+	South:     {X: 0, Y: 1},
+	SouthWest: {X: -1, Y: 1},
+	West:      {X: -1, Y: 0},
+	NorthWest: {X: -1, Y: -1},
+}
+
+var directionNames = [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// The position one step away in this direction, relative to the origin.
+func (d Direction) Offset() Point2 {
+	return directionOffsets[d]
+}
+
+func (d Direction) TurnRight() Direction {
+	return (d + 2) % 8
+}
+
+func (d Direction) TurnLeft() Direction {
+	return (d + 6) % 8
+}
+
+func (d Direction) Opposite() Direction {
+	return (d + 4) % 8
 }
 
+func (d Direction) String() string {
+	return directionNames[d]
+}
+
+// The step from p in direction d.
+func (p Point2) Step(d Direction) Point2 {
+	return p.Add(d.Offset())
+}
+
+var (
+	OrthogonalDirections = []Direction{North, East, South, West}
+	DiagonalDirections   = []Direction{NorthEast, SouthEast, SouthWest, NorthWest}
+	AllDirections        = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+)
+
+// The relative positions of the 4 cells sharing an edge with a cell in a 2D grid
+var OrthogonalNeighbours = Map(OrthogonalDirections, Direction.Offset)
+
+// The relative positions of the 4 cells touching a cell in a 2D grid only at a corner
+var DiagonalNeighbours = Map(DiagonalDirections, Direction.Offset)
+
+// The 8 relative positions around a cell in a 2D grid
+var AllNeighbours = Map(AllDirections, Direction.Offset)
+
 type Point3 struct {
 	X int
 	Y int
 	Z int
 }
 
+func (p Point3) Add(other Point3) Point3 {
+	return Point3{X: p.X + other.X, Y: p.Y + other.Y, Z: p.Z + other.Z}
+}
+
+func (p Point3) Sub(other Point3) Point3 {
+	return Point3{X: p.X - other.X, Y: p.Y - other.Y, Z: p.Z - other.Z}
+}
+
+func (p Point3) Scale(k int) Point3 {
+	return Point3{X: p.X * k, Y: p.Y * k, Z: p.Z * k}
+}
+
+func (p Point3) Neg() Point3 {
+	return Point3{X: -p.X, Y: -p.Y, Z: -p.Z}
+}
+
+// The square of the straight line distance between two points. Unlike DistanceTo it's exact, so it's the one to sort
+// by; squaring doesn't change the order. Errors if the result doesn't fit in an int64.
+func (p Point3) SquaredDistanceTo(other Point3) (int64, error) {
+	var total int64
+
+	for _, axis := range [][2]int{{p.X, other.X}, {p.Y, other.Y}, {p.Z, other.Z}} {
+		diff, err := SubChecked(int64(axis[1]), int64(axis[0]))
+		if err != nil {
+			return 0, fmt.Errorf("squared distance from %v to %v: %w", p, other, err)
+		}
+
+		square, err := MulChecked(diff, diff)
+		if err != nil {
+			return 0, fmt.Errorf("squared distance from %v to %v: %w", p, other, err)
+		}
+
+		if total, err = AddChecked(total, square); err != nil {
+			return 0, fmt.Errorf("squared distance from %v to %v: %w", p, other, err)
+		}
+	}
+
+	return total, nil
+}
+
+// The number of axis-aligned steps between two points.
+func (p Point3) Manhattan(other Point3) int {
+	return AbsInt(p.X-other.X) + AbsInt(p.Y-other.Y) + AbsInt(p.Z-other.Z)
+}
+
+// The number of steps between two points when diagonal steps are allowed.
+func (p Point3) Chebyshev(other Point3) int {
+	return max(AbsInt(p.X-other.X), AbsInt(p.Y-other.Y), AbsInt(p.Z-other.Z))
+}
+
 func (p Point3) DistanceTo(other Point3) float64 {
 	return math.Sqrt(
 		math.Pow(float64(other.X-p.X), 2) + math.Pow(float64(other.Y-p.Y), 2) + math.Pow(float64(other.Z-p.Z), 2),